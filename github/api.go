@@ -13,6 +13,8 @@ type IssueService interface {
 	CreateComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
 	EditComment(ctx context.Context, owner, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
 	ListComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error)
+	DeleteComment(ctx context.Context, owner, repo string, commentID int64) (*github.Response, error)
+	ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error)
 }
 
 type IssueServiceImpl struct {
@@ -39,3 +41,79 @@ func (s *IssueServiceImpl) EditComment(ctx context.Context, owner, repo string,
 func (s *IssueServiceImpl) ListComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
 	return s.client.Issues.ListComments(ctx, owner, repo, number, opts)
 }
+
+// DeleteComment wraps the DeleteComment method of the github.IssuesService.
+//
+//nolint:lll
+func (s *IssueServiceImpl) DeleteComment(ctx context.Context, owner, repo string, commentID int64) (*github.Response, error) {
+	return s.client.Issues.DeleteComment(ctx, owner, repo, commentID)
+}
+
+// ListPullRequestsWithCommit wraps the ListPullRequestsWithCommit method of the github.PullRequestsService.
+//
+//nolint:lll
+func (s *IssueServiceImpl) ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+	return s.client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, sha, opts)
+}
+
+// CommitService is an interface that wraps the commit comment methods of the GitHub API client.
+//
+//nolint:lll
+type CommitService interface {
+	CreateComment(ctx context.Context, owner, repo, sha string, comment *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error)
+	UpdateComment(ctx context.Context, owner, repo string, id int64, comment *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error)
+	DeleteComment(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+	ListCommitComments(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.RepositoryComment, *github.Response, error)
+}
+
+type CommitServiceImpl struct {
+	client *github.Client
+}
+
+// CreateComment wraps the CreateComment method of the github.RepositoriesService.
+//
+//nolint:lll
+func (s *CommitServiceImpl) CreateComment(ctx context.Context, owner, repo, sha string, comment *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error) {
+	return s.client.Repositories.CreateComment(ctx, owner, repo, sha, comment)
+}
+
+// UpdateComment wraps the UpdateComment method of the github.RepositoriesService.
+//
+//nolint:lll
+func (s *CommitServiceImpl) UpdateComment(ctx context.Context, owner, repo string, id int64, comment *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error) {
+	return s.client.Repositories.UpdateComment(ctx, owner, repo, id, comment)
+}
+
+// DeleteComment wraps the DeleteComment method of the github.RepositoriesService.
+func (s *CommitServiceImpl) DeleteComment(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	return s.client.Repositories.DeleteComment(ctx, owner, repo, id)
+}
+
+// ListCommitComments wraps the ListCommitComments method of the github.RepositoriesService.
+//
+//nolint:lll
+func (s *CommitServiceImpl) ListCommitComments(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.RepositoryComment, *github.Response, error) {
+	return s.client.Repositories.ListCommitComments(ctx, owner, repo, sha, opts)
+}
+
+// GistService is an interface that wraps the gist methods of the GitHub API client.
+type GistService interface {
+	Create(ctx context.Context, gist *github.Gist) (*github.Gist, *github.Response, error)
+	Edit(ctx context.Context, id string, gist *github.Gist) (*github.Gist, *github.Response, error)
+}
+
+type GistServiceImpl struct {
+	client *github.Client
+}
+
+// Create wraps the Create method of the github.GistsService.
+func (s *GistServiceImpl) Create(ctx context.Context, gist *github.Gist) (*github.Gist, *github.Response, error) {
+	return s.client.Gists.Create(ctx, gist)
+}
+
+// Edit wraps the Edit method of the github.GistsService.
+//
+//nolint:lll
+func (s *GistServiceImpl) Edit(ctx context.Context, id string, gist *github.Gist) (*github.Gist, *github.Response, error) {
+	return s.client.Gists.Edit(ctx, id, gist)
+}
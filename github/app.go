@@ -0,0 +1,205 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// GitHub rejects JWTs with an expiration time more than 10 minutes into the future.
+	appJWTExpiration = 9 * time.Minute
+	// Issue the JWT slightly in the past to allow for clock drift.
+	appJWTClockDrift = 60 * time.Second
+)
+
+var ErrInvalidPrivateKey = errors.New("invalid private key")
+
+// AppOptions holds the settings to authenticate as GitHub App installation.
+type AppOptions struct {
+	AppID          int64
+	InstallationID int64
+	PrivateKey     []byte
+	// Owner and Repo are used to discover the installation if no InstallationID is set.
+	Owner string
+	Repo  string
+}
+
+// AppTokenSource is an oauth2.TokenSource that mints installation access tokens for a
+// GitHub App from a JWT signed by the app private key.
+//
+//nolint:containedctx
+type AppTokenSource struct {
+	ctx    context.Context
+	client *github.Client
+	opt    AppOptions
+	reuse  oauth2.TokenSource
+}
+
+// jwtTransport is a http.RoundTripper that authenticates requests as GitHub App using a JWT.
+type jwtTransport struct {
+	base  http.RoundTripper
+	appID int64
+	key   *rsa.PrivateKey
+}
+
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+// NewAppTokenSource creates a new token source for the GitHub App installation. The
+// token source caches the installation access token until it expires.
+func NewAppTokenSource(
+	ctx context.Context, url *url.URL, client *http.Client, opt AppOptions,
+) (*AppTokenSource, error) {
+	key, err := parsePrivateKey(opt.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	base := http.DefaultTransport
+	if client != nil && client.Transport != nil {
+		base = client.Transport
+	}
+
+	c := github.NewClient(&http.Client{
+		Transport: &jwtTransport{base: base, appID: opt.AppID, key: key},
+	})
+	c.BaseURL = url
+
+	ts := &AppTokenSource{
+		ctx:    ctx,
+		client: c,
+		opt:    opt,
+	}
+	ts.reuse = oauth2.ReuseTokenSource(nil, tokenSourceFunc(ts.newToken))
+
+	return ts, nil
+}
+
+// Token returns a cached or new installation access token.
+func (s *AppTokenSource) Token() (*oauth2.Token, error) {
+	return s.reuse.Token()
+}
+
+// Login returns the login of the bot user of the GitHub App, which is the app slug
+// with a `[bot]` suffix.
+func (s *AppTokenSource) Login(ctx context.Context) (string, error) {
+	app, _, err := s.client.Apps.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get github app: %w", err)
+	}
+
+	return app.GetSlug() + "[bot]", nil
+}
+
+// newToken returns a new installation access token. If no installation ID is configured,
+// the installation is discovered from the repository.
+func (s *AppTokenSource) newToken() (*oauth2.Token, error) {
+	if s.opt.InstallationID == 0 {
+		installation, _, err := s.client.Apps.FindRepositoryInstallation(s.ctx, s.opt.Owner, s.opt.Repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find app installation for %s/%s: %w", s.opt.Owner, s.opt.Repo, err)
+		}
+
+		s.opt.InstallationID = installation.GetID()
+	}
+
+	token, _, err := s.client.Apps.CreateInstallationToken(s.ctx, s.opt.InstallationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create installation token: %w", err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.GetToken(),
+		TokenType:   "token",
+		Expiry:      token.GetExpiresAt().Time,
+	}, nil
+}
+
+// RoundTrip adds a freshly signed JWT to the request.
+func (t *jwtTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := signJWT(t.appID, t.key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return t.base.RoundTrip(req)
+}
+
+// signJWT returns a RS256 signed JWT for the GitHub App with the given ID.
+func signJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appJWTClockDrift).Unix(),
+		"exp": now.Add(appJWTExpiration).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign jwt: %w", err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parsePrivateKey parses a PEM encoded RSA private key in PKCS#1 or PKCS#8 format.
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		// Keys passed via environment variables often contain escaped line breaks.
+		block, _ = pem.Decode(bytes.ReplaceAll(data, []byte(`\n`), []byte("\n")))
+	}
+
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM data found", ErrInvalidPrivateKey)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPrivateKey, err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: not an RSA key", ErrInvalidPrivateKey)
+	}
+
+	return key, nil
+}
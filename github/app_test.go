@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+
+	token, err := signJWT(42, key, now)
+	assert.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	assert.Len(t, parts, 3)
+
+	claims := map[string]any{}
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	assert.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, "42", claims["iss"])
+	assert.InDelta(t, now.Add(-appJWTClockDrift).Unix(), claims["iat"], 0)
+	assert.InDelta(t, now.Add(appJWTExpiration).Unix(), claims["exp"], 0)
+
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature))
+}
+
+func TestAppTokenSource_Token(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	// Escaped line breaks are accepted as well.
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	pemKey = []byte(strings.ReplaceAll(string(pemKey), "\n", `\n`))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/octocat/hello-world/installation", func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Bearer "))
+
+		_, _ = w.Write([]byte(`{"id": 7}`))
+	})
+	mux.HandleFunc("POST /app/installations/7/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Bearer "))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"token": "ghs_test", "expires_at": "2099-01-01T00:00:00Z"}`))
+	})
+	mux.HandleFunc("GET /app", func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Bearer "))
+
+		_, _ = w.Write([]byte(`{"id": 42, "slug": "ci-bot"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL + "/")
+
+	ts, err := NewAppTokenSource(context.Background(), baseURL, server.Client(), AppOptions{
+		AppID:      42,
+		PrivateKey: pemKey,
+		Owner:      "octocat",
+		Repo:       "hello-world",
+	})
+	assert.NoError(t, err)
+
+	token, err := ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "ghs_test", token.AccessToken)
+
+	login, err := ts.Login(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "ci-bot[bot]", login)
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	_, err := parsePrivateKey([]byte("invalid"))
+	assert.ErrorIs(t, err, ErrInvalidPrivateKey)
+}
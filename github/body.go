@@ -0,0 +1,46 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	UpdateModeReplace = "replace"
+	UpdateModeAppend  = "append"
+	UpdateModePrepend = "prepend"
+
+	entrySeparator = "<!-- entry -->"
+)
+
+// parseEntries splits the given comment body into its entries. The metadata block is
+// removed before splitting, a body without entry separators is a single entry.
+func parseEntries(body string) []string {
+	return strings.Split(stripMetadata(body), fmt.Sprintf("\n%s\n", entrySeparator))
+}
+
+// mergeEntries adds the message to the entries of the existing comment body according
+// to the update mode and returns the new comment content without the metadata block.
+// If maxEntries is greater than zero, only the newest maxEntries entries are retained.
+func mergeEntries(existing, message, mode string, maxEntries int) string {
+	var entries []string
+
+	switch mode {
+	case UpdateModeAppend:
+		entries = append(parseEntries(existing), message)
+
+		if maxEntries > 0 && len(entries) > maxEntries {
+			entries = entries[len(entries)-maxEntries:]
+		}
+	case UpdateModePrepend:
+		entries = append([]string{message}, parseEntries(existing)...)
+
+		if maxEntries > 0 && len(entries) > maxEntries {
+			entries = entries[:maxEntries]
+		}
+	default:
+		entries = []string{message}
+	}
+
+	return strings.Join(entries, fmt.Sprintf("\n%s\n", entrySeparator))
+}
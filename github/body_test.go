@@ -0,0 +1,57 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeEntries(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   string
+		mode       string
+		maxEntries int
+		want       string
+	}{
+		{
+			name:     "replace",
+			existing: "old\n<!-- id: key -->\n",
+			mode:     UpdateModeReplace,
+			want:     "new",
+		},
+		{
+			name:     "append to legacy comment",
+			existing: "old\n<!-- id: key -->\n",
+			mode:     UpdateModeAppend,
+			want:     "old\n<!-- entry -->\nnew",
+		},
+		{
+			name:     "prepend",
+			existing: "old\n<!-- id: key -->\n",
+			mode:     UpdateModePrepend,
+			want:     "new\n<!-- entry -->\nold",
+		},
+		{
+			name:       "append with max entries",
+			existing:   "one\n<!-- entry -->\ntwo\n<!-- entry -->\nthree\n<!-- id: key -->\n",
+			mode:       UpdateModeAppend,
+			maxEntries: 2,
+			want:       "three\n<!-- entry -->\nnew",
+		},
+		{
+			name:       "prepend with max entries",
+			existing:   "one\n<!-- entry -->\ntwo\n<!-- entry -->\nthree\n<!-- id: key -->\n",
+			mode:       UpdateModePrepend,
+			maxEntries: 2,
+			want:       "new\n<!-- entry -->\none",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeEntries(tt.existing, "new", tt.mode, tt.maxEntries)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v67/github"
+)
+
+type Commit struct {
+	client CommitService
+	gists  GistService
+	Opt    CommitOptions
+}
+
+type CommitOptions struct {
+	SHA         string
+	Message     string
+	Key         string
+	Repo        string
+	Owner       string
+	Update      bool
+	UpdateMode  string
+	MaxEntries  int
+	Authors     []string
+	Metadata    Metadata
+	LegacyKey   string
+	Overflow    string
+	OverflowURL string
+}
+
+// AddComment adds a new comment or updates the existing comment with the key on a GitHub
+// commit and returns the performed action. Unchanged content is not edited.
+func (c *Commit) AddComment(ctx context.Context) (*github.RepositoryComment, Action, error) {
+	var existing *github.RepositoryComment
+
+	content := c.Opt.Message
+
+	if c.Opt.Update {
+		var err error
+
+		existing, err = c.FindComment(ctx)
+		if err != nil && !errors.Is(err, ErrCommentNotFound) {
+			return nil, "", err
+		}
+
+		if existing != nil {
+			content = mergeEntries(existing.GetBody(), c.Opt.Message, c.Opt.UpdateMode, c.Opt.MaxEntries)
+		}
+	}
+
+	meta := c.Opt.Metadata
+
+	if c.Opt.Overflow == OverflowGist {
+		var err error
+
+		description := fmt.Sprintf("%s/%s@%s", c.Opt.Owner, c.Opt.Repo, c.Opt.SHA)
+
+		if content, err = uploadGist(ctx, c.gists, content, c.Opt.Key, description, existing.GetBody(), &meta); err != nil {
+			return nil, "", err
+		}
+	}
+
+	parts, err := fitContent(content, contentLimit(MaxCommentLength, c.Opt.Key, meta), c.Opt.Overflow, c.Opt.OverflowURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(parts) > 1 {
+		return nil, "", fmt.Errorf("%w: splitting is not supported for commit comments", ErrCommentTooLong)
+	}
+
+	// Append plugin metadata to comment message so we can search for it later
+	commitComment := &github.RepositoryComment{
+		Body: github.String(withMetadata(parts[0], c.Opt.Key, meta)),
+	}
+
+	if existing != nil {
+		// Comments found by the legacy key are migrated to the new key.
+		if sameContent(existing.GetBody(), parts[0]) && hasKey(existing.GetBody(), c.Opt.Key) {
+			return existing, ActionUnchanged, nil
+		}
+
+		comment, _, err := c.client.UpdateComment(ctx, c.Opt.Owner, c.Opt.Repo, existing.GetID(), commitComment)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return comment, ActionUpdated, nil
+	}
+
+	comment, _, err := c.client.CreateComment(ctx, c.Opt.Owner, c.Opt.Repo, c.Opt.SHA, commitComment)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return comment, ActionCreated, nil
+}
+
+// DeleteComment deletes the GitHub commit comment that contains the specified key.
+// It returns the deleted comment, or ErrCommentNotFound if no such comment exists.
+func (c *Commit) DeleteComment(ctx context.Context) (*github.RepositoryComment, error) {
+	comment, err := c.FindComment(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.client.DeleteComment(ctx, c.Opt.Owner, c.Opt.Repo, comment.GetID()); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// FindComment returns the newest GitHub commit comment that contains the specified key or, as fallback,
+// the LegacyKey, or nil if no such comment exists.
+func (c *Commit) FindComment(ctx context.Context) (*github.RepositoryComment, error) {
+	var match, legacy *github.RepositoryComment
+
+	list := func(page int) ([]*github.RepositoryComment, *github.Response, error) {
+		opts := &github.ListOptions{Page: page, PerPage: commentsPerPage}
+
+		return c.client.ListCommitComments(ctx, c.Opt.Owner, c.Opt.Repo, c.Opt.SHA, opts)
+	}
+
+	err := scanNewestFirst(list, func(comment *github.RepositoryComment) bool {
+		if !isAuthor(comment.GetUser(), c.Opt.Authors) {
+			return true
+		}
+
+		if !hasKey(comment.GetBody(), c.Opt.Key) {
+			if legacy == nil && c.Opt.LegacyKey != "" && hasKey(comment.GetBody(), c.Opt.LegacyKey) {
+				legacy = comment
+			}
+
+			return true
+		}
+
+		match = comment
+
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if match == nil {
+		match = legacy
+	}
+
+	if match == nil {
+		return nil, fmt.Errorf("%w: failed to find comment with key %s", ErrCommentNotFound, c.Opt.Key)
+	}
+
+	return match, nil
+}
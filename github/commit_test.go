@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thegeeklab/wp-github-comment/github/mocks"
+)
+
+func TestGithubCommit_AddComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		commitOpt  CommitOptions
+		comments   []*github.RepositoryComment
+		wantAction Action
+		wantErr    error
+	}{
+		{
+			name: "create new comment",
+			commitOpt: CommitOptions{
+				Key:     "test-key",
+				SHA:     "test-sha",
+				Message: "test message",
+			},
+		},
+		{
+			name: "update existing comment",
+			commitOpt: CommitOptions{
+				Key:     "test-key",
+				SHA:     "test-sha",
+				Message: "test message",
+				Update:  true,
+			},
+			comments: []*github.RepositoryComment{
+				{ID: github.Int64(1), Body: github.String("other comment")},
+				{ID: github.Int64(123), Body: github.String("old message\n<!-- id: test-key -->\n")},
+			},
+			wantAction: ActionUpdated,
+		},
+		{
+			name: "skip unchanged comment",
+			commitOpt: CommitOptions{
+				Key:     "test-key",
+				SHA:     "test-sha",
+				Message: "test message",
+				Update:  true,
+			},
+			comments: []*github.RepositoryComment{
+				{ID: github.Int64(123), Body: github.String(withMetadata("test message", "test-key", Metadata{}))},
+			},
+			wantAction: ActionUnchanged,
+		},
+		{
+			name: "migrate unchanged legacy comment",
+			commitOpt: CommitOptions{
+				Key:       "test-key",
+				LegacyKey: "legacy-key",
+				SHA:       "test-sha",
+				Message:   "test message",
+				Update:    true,
+			},
+			comments: []*github.RepositoryComment{
+				{ID: github.Int64(123), Body: github.String(withMetadata("test message", "legacy-key", Metadata{}))},
+			},
+			wantAction: ActionUpdated,
+		},
+		{
+			name: "update non-existing comment",
+			commitOpt: CommitOptions{
+				Key:     "test-key",
+				SHA:     "test-sha",
+				Message: "test message",
+				Update:  true,
+			},
+		},
+		{
+			name: "create new comment with error",
+			commitOpt: CommitOptions{
+				Key:     "test-key",
+				SHA:     "test-sha",
+				Message: "test message",
+			},
+			wantErr: ErrInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := mocks.NewMockCommitService(t)
+			commit := &Commit{
+				client: mockClient,
+				Opt:    tt.commitOpt,
+			}
+
+			want := &github.RepositoryComment{Body: github.String(withMetadata("test message", "test-key", Metadata{}))}
+			if tt.commitOpt.Update {
+				mockClient.
+					On("ListCommitComments", mock.Anything, mock.Anything, mock.Anything, "test-sha", mock.Anything).
+					Return(tt.comments, nil, nil)
+			}
+
+			switch tt.wantAction {
+			case ActionUnchanged:
+				want = tt.comments[0]
+			case ActionUpdated:
+				mockClient.
+					On("UpdateComment", mock.Anything, mock.Anything, mock.Anything, int64(123), want).
+					Return(want, nil, nil)
+			default:
+				var ret *github.RepositoryComment
+				if tt.wantErr == nil {
+					ret = want
+				}
+
+				mockClient.
+					On("CreateComment", mock.Anything, mock.Anything, mock.Anything, "test-sha", want).
+					Return(ret, nil, tt.wantErr)
+			}
+
+			got, action, err := commit.AddComment(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			if tt.wantAction != "" {
+				assert.Equal(t, tt.wantAction, action)
+			}
+		})
+	}
+}
@@ -0,0 +1,400 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/rs/zerolog/log"
+)
+
+// dryRunGistID is the ID of gists created during a dry run.
+const dryRunGistID = "dry-run"
+
+// DryRun replaces the services of the client with implementations that print write requests to out
+// instead of executing them. Read requests are only sent if offline is false.
+func (c *Client) DryRun(offline bool, out io.Writer) {
+	var (
+		issue   IssueService
+		commit  CommitService
+		graphql GraphQLService
+	)
+
+	if !offline {
+		issue, commit, graphql = c.Issue.client, c.Commit.client, c.Issue.graphql
+	}
+
+	author := func() string { return c.login }
+
+	c.Issue.client = &dryRunIssueService{service: issue, out: out, author: author}
+	c.Issue.graphql = &dryRunGraphQLService{service: graphql, out: out}
+	c.Issue.settleDelay = 0
+	c.Commit.client = &dryRunCommitService{service: commit, out: out, author: author}
+
+	if c.Issue.gists != nil {
+		gists := &dryRunGistService{out: out}
+		c.Issue.gists, c.Commit.gists = gists, gists
+	}
+}
+
+// dryRunIssueService is an IssueService that only executes read requests.
+type dryRunIssueService struct {
+	service  IssueService
+	out      io.Writer
+	author   func() string
+	comments map[int][]*github.IssueComment
+	lastID   int64
+}
+
+func (s *dryRunIssueService) CreateComment(
+	ctx context.Context, owner, repo string, number int, comment *github.IssueComment,
+) (*github.IssueComment, *github.Response, error) {
+	comments, err := s.load(ctx, owner, repo, number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.lastID--
+	created := &github.IssueComment{
+		ID:   github.Int64(s.lastID),
+		Body: comment.Body,
+		User: &github.User{Login: github.String(s.author())},
+	}
+	s.comments[number] = append(comments, created)
+
+	printDryRun(s.out, fmt.Sprintf("create comment on #%d", number), "", created.GetBody())
+
+	return created, nil, nil
+}
+
+func (s *dryRunIssueService) EditComment(
+	_ context.Context, _, _ string, commentID int64, comment *github.IssueComment,
+) (*github.IssueComment, *github.Response, error) {
+	for number, comments := range s.comments {
+		idx := slices.IndexFunc(comments, func(c *github.IssueComment) bool { return c.GetID() == commentID })
+		if idx < 0 {
+			continue
+		}
+
+		current := comments[idx]
+		edited := *current
+		edited.Body = comment.Body
+		s.comments[number][idx] = &edited
+
+		request := fmt.Sprintf("edit comment %d on #%d", commentID, number)
+		printDryRun(s.out, request, current.GetBody(), edited.GetBody())
+
+		return &edited, nil, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: comment %d", ErrCommentNotFound, commentID)
+}
+
+func (s *dryRunIssueService) ListComments(
+	ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions,
+) ([]*github.IssueComment, *github.Response, error) {
+	comments, err := s.load(ctx, owner, repo, number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, resp := paginate(comments, opts.ListOptions)
+
+	return items, resp, nil
+}
+
+func (s *dryRunIssueService) DeleteComment(_ context.Context, _, _ string, commentID int64) (*github.Response, error) {
+	for number, comments := range s.comments {
+		idx := slices.IndexFunc(comments, func(c *github.IssueComment) bool { return c.GetID() == commentID })
+		if idx < 0 {
+			continue
+		}
+
+		printDryRun(s.out, fmt.Sprintf("delete comment %d on #%d", commentID, number), comments[idx].GetBody(), "")
+
+		s.comments[number] = slices.Concat(comments[:idx], comments[idx+1:])
+
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("%w: comment %d", ErrCommentNotFound, commentID)
+}
+
+func (s *dryRunIssueService) ListPullRequestsWithCommit(
+	ctx context.Context, owner, repo, sha string, opts *github.ListOptions,
+) ([]*github.PullRequest, *github.Response, error) {
+	if s.service == nil {
+		return nil, nil, nil
+	}
+
+	return s.service.ListPullRequestsWithCommit(ctx, owner, repo, sha, opts)
+}
+
+// load returns the comments of the given issue. The comments are requested once and
+// subsequently served from memory.
+func (s *dryRunIssueService) load(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error) {
+	if s.comments == nil {
+		s.comments = make(map[int][]*github.IssueComment)
+	}
+
+	if comments, ok := s.comments[number]; ok || s.service == nil {
+		return comments, nil
+	}
+
+	comments, err := listAll(func(opts github.ListOptions) ([]*github.IssueComment, *github.Response, error) {
+		return s.service.ListComments(ctx, owner, repo, number, &github.IssueListCommentsOptions{ListOptions: opts})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.comments[number] = comments
+
+	return comments, nil
+}
+
+// dryRunCommitService is a CommitService that only executes read requests.
+type dryRunCommitService struct {
+	service  CommitService
+	out      io.Writer
+	author   func() string
+	comments map[string][]*github.RepositoryComment
+	lastID   int64
+}
+
+func (s *dryRunCommitService) CreateComment(
+	ctx context.Context, owner, repo, sha string, comment *github.RepositoryComment,
+) (*github.RepositoryComment, *github.Response, error) {
+	comments, err := s.load(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.lastID--
+	created := &github.RepositoryComment{
+		ID:   github.Int64(s.lastID),
+		Body: comment.Body,
+		User: &github.User{Login: github.String(s.author())},
+	}
+	s.comments[sha] = append(comments, created)
+
+	printDryRun(s.out, "create comment on commit "+sha, "", created.GetBody())
+
+	return created, nil, nil
+}
+
+func (s *dryRunCommitService) UpdateComment(
+	_ context.Context, _, _ string, id int64, comment *github.RepositoryComment,
+) (*github.RepositoryComment, *github.Response, error) {
+	for sha, comments := range s.comments {
+		idx := slices.IndexFunc(comments, func(c *github.RepositoryComment) bool { return c.GetID() == id })
+		if idx < 0 {
+			continue
+		}
+
+		current := comments[idx]
+		edited := *current
+		edited.Body = comment.Body
+		s.comments[sha][idx] = &edited
+
+		request := fmt.Sprintf("edit comment %d on commit %s", id, sha)
+		printDryRun(s.out, request, current.GetBody(), edited.GetBody())
+
+		return &edited, nil, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: comment %d", ErrCommentNotFound, id)
+}
+
+func (s *dryRunCommitService) DeleteComment(_ context.Context, _, _ string, id int64) (*github.Response, error) {
+	for sha, comments := range s.comments {
+		idx := slices.IndexFunc(comments, func(c *github.RepositoryComment) bool { return c.GetID() == id })
+		if idx < 0 {
+			continue
+		}
+
+		printDryRun(s.out, fmt.Sprintf("delete comment %d on commit %s", id, sha), comments[idx].GetBody(), "")
+
+		s.comments[sha] = slices.Concat(comments[:idx], comments[idx+1:])
+
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("%w: comment %d", ErrCommentNotFound, id)
+}
+
+func (s *dryRunCommitService) ListCommitComments(
+	ctx context.Context, owner, repo, sha string, opts *github.ListOptions,
+) ([]*github.RepositoryComment, *github.Response, error) {
+	comments, err := s.load(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, resp := paginate(comments, *opts)
+
+	return items, resp, nil
+}
+
+// load returns the comments of the given commit. The comments are requested once and
+// subsequently served from memory.
+func (s *dryRunCommitService) load(ctx context.Context, owner, repo, sha string) ([]*github.RepositoryComment, error) {
+	if s.comments == nil {
+		s.comments = make(map[string][]*github.RepositoryComment)
+	}
+
+	if comments, ok := s.comments[sha]; ok || s.service == nil {
+		return comments, nil
+	}
+
+	comments, err := listAll(func(opts github.ListOptions) ([]*github.RepositoryComment, *github.Response, error) {
+		return s.service.ListCommitComments(ctx, owner, repo, sha, &opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.comments[sha] = comments
+
+	return comments, nil
+}
+
+// dryRunGraphQLService is a GraphQLService that only executes queries.
+type dryRunGraphQLService struct {
+	service GraphQLService
+	out     io.Writer
+}
+
+func (s *dryRunGraphQLService) MinimizedComments(ctx context.Context, ids []string) (map[string]bool, error) {
+	// Comments created during the dry run have no node ID.
+	ids = slices.DeleteFunc(slices.Clone(ids), func(id string) bool { return id == "" })
+
+	if s.service == nil || len(ids) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	return s.service.MinimizedComments(ctx, ids)
+}
+
+func (s *dryRunGraphQLService) MinimizeComment(_ context.Context, id, classifier string) error {
+	printDryRun(s.out, fmt.Sprintf("minimize comment %s as %s", id, classifier), "", "")
+
+	return nil
+}
+
+// dryRunGistService is a GistService that does not execute any request.
+type dryRunGistService struct {
+	out io.Writer
+}
+
+func (s *dryRunGistService) Create(_ context.Context, gist *github.Gist) (*github.Gist, *github.Response, error) {
+	printDryRun(s.out, "create secret gist", "", gistContent(gist))
+
+	return dryRunGist(dryRunGistID), nil, nil
+}
+
+func (s *dryRunGistService) Edit(
+	_ context.Context, id string, gist *github.Gist,
+) (*github.Gist, *github.Response, error) {
+	// The current content of the gist is not requested, so the diff shows the full content.
+	printDryRun(s.out, "edit gist "+id, "", gistContent(gist))
+
+	return dryRunGist(id), nil, nil
+}
+
+// dryRunGist returns a gist with the given ID and a placeholder URL.
+func dryRunGist(id string) *github.Gist {
+	return &github.Gist{ID: github.String(id), HTMLURL: github.String("https://gist.github.com/" + id)}
+}
+
+// gistContent returns the content of the comment file of the gist.
+func gistContent(gist *github.Gist) string {
+	file := gist.GetFiles()[gistFile]
+
+	return file.GetContent()
+}
+
+// listAll requests all pages of a paginated list.
+func listAll[T any](list func(opts github.ListOptions) ([]T, *github.Response, error)) ([]T, error) {
+	var all []T
+
+	opts := github.ListOptions{PerPage: commentsPerPage}
+
+	for {
+		items, resp, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+
+		if resp == nil || resp.NextPage == 0 {
+			return all, nil
+		}
+
+		opts.Page = resp.NextPage
+	}
+}
+
+// paginate returns the requested page of the given items along with a response that
+// holds the pagination details.
+func paginate[T any](items []T, opts github.ListOptions) ([]T, *github.Response) {
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = commentsPerPage
+	}
+
+	page := max(opts.Page, 1)
+	last := max((len(items)+perPage-1)/perPage, 1)
+
+	resp := &github.Response{LastPage: last}
+	if page < last {
+		resp.NextPage = page + 1
+	}
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+
+	return items[start:end], resp
+}
+
+// printDryRun prints the request that is not executed and the diff of the comment body.
+func printDryRun(out io.Writer, request, before, after string) {
+	log.Info().Str("request", request).Msg("dry run: request not executed")
+
+	if before == after {
+		return
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(before),
+		B:        diffLines(after),
+		FromFile: "current",
+		ToFile:   "dry-run",
+		Context:  3,
+	})
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintln(out, diff)
+}
+
+// diffLines splits the text into lines for the diff, each line including its line break.
+func diffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+
+	return lines[:len(lines)-1]
+}
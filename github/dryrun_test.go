@@ -0,0 +1,108 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thegeeklab/wp-github-comment/github/mocks"
+)
+
+func TestDryRun_Issue(t *testing.T) {
+	var out bytes.Buffer
+
+	mockClient := mocks.NewMockIssueService(t)
+	mockClient.
+		On("ListComments", mock.Anything, "test-owner", "test-repo", 1, mock.Anything).
+		Return([]*github.IssueComment{
+			{ID: github.Int64(123), Body: github.String(withMetadata("old message", "test-key", Metadata{}))},
+		}, nil, nil).
+		Once()
+
+	client := &Client{
+		Issue:  &Issue{client: mockClient},
+		Commit: &Commit{},
+	}
+	client.DryRun(false, &out)
+
+	client.Issue.Opt = IssueOptions{
+		Number:  1,
+		Key:     "test-key",
+		Owner:   "test-owner",
+		Repo:    "test-repo",
+		Message: "new message",
+		Update:  true,
+	}
+
+	got, action, err := client.Issue.AddComment(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ActionUpdated, action)
+	assert.Equal(t, int64(123), got.GetID())
+	assert.Contains(t, out.String(), "\n-old message\n")
+	assert.Contains(t, out.String(), "\n+new message\n")
+
+	// Subsequent lookups reflect the previous write.
+	got, action, err = client.Issue.AddComment(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ActionUnchanged, action)
+	assert.Equal(t, int64(123), got.GetID())
+
+	_, err = client.Issue.DeleteComment(context.Background())
+	assert.NoError(t, err)
+
+	_, err = client.Issue.FindComment(context.Background())
+	assert.ErrorIs(t, err, ErrCommentNotFound)
+}
+
+func TestDryRun_Offline(t *testing.T) {
+	var out bytes.Buffer
+
+	client := &Client{
+		Issue:  &Issue{client: mocks.NewMockIssueService(t), settleDelay: sectionSettleDelay},
+		Commit: &Commit{client: mocks.NewMockCommitService(t)},
+	}
+	client.DryRun(true, &out)
+
+	client.Issue.Opt = IssueOptions{
+		Number:  1,
+		Key:     "test-key",
+		Message: "test message",
+		Section: "test",
+	}
+
+	_, action, err := client.Issue.AddComment(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ActionCreated, action)
+	assert.Contains(t, out.String(), "+<!-- section: test -->\n+test message\n")
+
+	client.Commit.Opt = CommitOptions{
+		SHA:     "test-sha",
+		Key:     "test-key",
+		Message: "test message",
+	}
+
+	_, action, err = client.Commit.AddComment(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ActionCreated, action)
+	assert.Contains(t, out.String(), "+test message\n")
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	got, resp := paginate(items, github.ListOptions{Page: 2, PerPage: 2})
+	assert.Equal(t, []int{3, 4}, got)
+	assert.Equal(t, 3, resp.LastPage)
+	assert.Equal(t, 3, resp.NextPage)
+
+	got, resp = paginate(items, github.ListOptions{Page: 3, PerPage: 2})
+	assert.Equal(t, []int{5}, got)
+	assert.Equal(t, 0, resp.NextPage)
+
+	got, resp = paginate([]int{}, github.ListOptions{})
+	assert.Empty(t, got)
+	assert.Equal(t, 1, resp.LastPage)
+}
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// gistFile is the name of the file in the gist that holds the full comment content.
+const gistFile = "comment.md"
+
+var ErrGistNotSupported = errors.New("gists are not supported")
+
+// uploadGist uploads content that exceeds the maximum comment length to a secret gist and
+// returns a summary that links to it. The gist is recorded in the metadata for later runs.
+func uploadGist(
+	ctx context.Context, gists GistService, content, key, description, existing string, meta *Metadata,
+) (string, error) {
+	current, currentMeta, _ := splitMetadata(existing)
+	meta.Gist = currentMeta.Gist
+
+	if len(content) <= contentLimit(MaxCommentLength, key, *meta) {
+		return content, nil
+	}
+
+	hash := contentHash(content)
+
+	// The gist is up to date, so the summary of the existing comment is still valid.
+	if meta.Gist != "" && currentMeta.GistHash == hash {
+		meta.GistHash = hash
+
+		if len(current) <= contentLimit(MaxCommentLength, key, *meta) {
+			return current, nil
+		}
+	}
+
+	if gists == nil {
+		return "", fmt.Errorf("%w: %w", ErrCommentTooLong, ErrGistNotSupported)
+	}
+
+	gist := &github.Gist{
+		Description: github.String(description),
+		Public:      github.Bool(false),
+		Files: map[github.GistFilename]github.GistFile{
+			gistFile: {Content: github.String(content)},
+		},
+	}
+
+	uploaded, err := writeGist(ctx, gists, meta.Gist, gist)
+	if err != nil {
+		return "", err
+	}
+
+	meta.Gist = uploaded.GetID()
+	meta.GistHash = hash
+
+	return gistSummary(content, contentLimit(MaxCommentLength, key, *meta), uploaded.GetHTMLURL()), nil
+}
+
+// writeGist updates the gist with the given ID, or creates a new gist if the ID is empty or
+// the gist was deleted in the meantime.
+func writeGist(ctx context.Context, gists GistService, id string, gist *github.Gist) (*github.Gist, error) {
+	if id != "" {
+		edited, _, err := gists.Edit(ctx, id, gist)
+		if err == nil {
+			return edited, nil
+		}
+
+		var errResp *github.ErrorResponse
+		if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusNotFound {
+			return nil, fmt.Errorf("failed to update gist %s: %w", id, err)
+		}
+	}
+
+	created, _, err := gists.Create(ctx, gist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gist: %w", err)
+	}
+
+	return created, nil
+}
+
+// gistSummary cuts the content to the given size and adds a footer that links to the gist
+// with the full content.
+func gistSummary(content string, size int, url string) string {
+	footer := fmt.Sprintf("\n\n---\n:page_facing_up: This comment was shortened because it exceeds the maximum "+
+		"length. The full content is available in a [gist](%s).", url)
+
+	head, _ := cutContent(content, size-len(footer))
+
+	return head + footer
+}
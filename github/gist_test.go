@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thegeeklab/wp-github-comment/github/mocks"
+)
+
+func TestUploadGist(t *testing.T) {
+	long := strings.Repeat("test line\n", MaxCommentLength/8)
+
+	t.Run("fits", func(t *testing.T) {
+		existing := withMetadata("old", "key", Metadata{Gist: "abc"})
+		meta := Metadata{}
+
+		got, err := uploadGist(context.Background(), nil, "test message", "key", "", existing, &meta)
+		assert.NoError(t, err)
+		assert.Equal(t, "test message", got)
+		assert.Equal(t, "abc", meta.Gist)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		_, err := uploadGist(context.Background(), nil, long, "key", "", "", &Metadata{})
+		assert.ErrorIs(t, err, ErrCommentTooLong)
+		assert.ErrorIs(t, err, ErrGistNotSupported)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		mockGists := mocks.NewMockGistService(t)
+		meta := Metadata{}
+
+		mockGists.
+			On("Create", mock.Anything, mock.MatchedBy(func(gist *github.Gist) bool {
+				return !gist.GetPublic() && gist.GetDescription() == "test-owner/test-repo#1" &&
+					gistContent(gist) == long
+			})).
+			Return(&github.Gist{ID: github.String("abc"), HTMLURL: github.String("https://gist.github.com/abc")}, nil, nil).
+			Once()
+
+		got, err := uploadGist(context.Background(), mockGists, long, "key", "test-owner/test-repo#1", "", &meta)
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", meta.Gist)
+		assert.Equal(t, contentHash(long), meta.GistHash)
+		assert.LessOrEqual(t, len(withMetadata(got, "key", meta)), MaxCommentLength)
+		assert.True(t, strings.HasPrefix(got, "test line\ntest line\n"))
+		assert.True(t, strings.HasSuffix(got, "The full content is available in a [gist](https://gist.github.com/abc)."))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		mockGists := mocks.NewMockGistService(t)
+		existing := withMetadata("summary", "key", Metadata{Gist: "abc", GistHash: contentHash("old")})
+		meta := Metadata{}
+
+		mockGists.
+			On("Edit", mock.Anything, "abc", mock.Anything).
+			Return(&github.Gist{ID: github.String("abc"), HTMLURL: github.String("https://gist.github.com/abc")}, nil, nil).
+			Once()
+
+		_, err := uploadGist(context.Background(), mockGists, long, "key", "", existing, &meta)
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", meta.Gist)
+		assert.Equal(t, contentHash(long), meta.GistHash)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		// No gist request is expected if the content did not change.
+		mockGists := mocks.NewMockGistService(t)
+		existing := withMetadata("summary", "key", Metadata{Gist: "abc", GistHash: contentHash(long)})
+		meta := Metadata{}
+
+		got, err := uploadGist(context.Background(), mockGists, long, "key", "", existing, &meta)
+		assert.NoError(t, err)
+		assert.Equal(t, "summary", got)
+		assert.Equal(t, Metadata{Gist: "abc", GistHash: contentHash(long)}, meta)
+	})
+}
+
+func TestGithubIssue_AddComment_Gist(t *testing.T) {
+	message := strings.Repeat("test line\n", MaxCommentLength/8)
+
+	mockClient := mocks.NewMockIssueService(t)
+	mockGists := mocks.NewMockGistService(t)
+	issue := &Issue{
+		client: mockClient,
+		gists:  mockGists,
+		Opt: IssueOptions{
+			Key:      "test-key",
+			Owner:    "test-owner",
+			Repo:     "test-repo",
+			Number:   1,
+			Message:  message,
+			Update:   true,
+			Overflow: OverflowGist,
+		},
+	}
+
+	mockClient.
+		On("ListComments", mock.Anything, "test-owner", "test-repo", 1, mock.Anything).
+		Return([]*github.IssueComment{
+			{ID: github.Int64(1), Body: github.String(withMetadata("old", "test-key", Metadata{Gist: "abc"}))},
+		}, nil, nil)
+
+	// The gist was deleted, so a new gist is created.
+	mockGists.
+		On("Edit", mock.Anything, "abc", mock.Anything).
+		Return(nil, nil, &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}).
+		Once()
+
+	mockGists.
+		On("Create", mock.Anything, mock.Anything).
+		Return(&github.Gist{ID: github.String("def"), HTMLURL: github.String("https://gist.github.com/def")}, nil, nil).
+		Once()
+
+	linked := mock.MatchedBy(func(c *github.IssueComment) bool {
+		meta, ok := parseMetadata(c.GetBody())
+
+		return ok && meta.Gist == "def" && strings.Contains(c.GetBody(), "[gist](https://gist.github.com/def)")
+	})
+
+	mockClient.
+		On("EditComment", mock.Anything, "test-owner", "test-repo", int64(1), linked).
+		Return(&github.IssueComment{ID: github.Int64(1)}, nil, nil).
+		Once()
+
+	_, action, err := issue.AddComment(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ActionUpdated, action)
+}
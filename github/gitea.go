@@ -0,0 +1,96 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/oauth2"
+)
+
+// GiteaIssueService implements the IssueService for the Gitea and Forgejo API. The issue
+// comment endpoints of Gitea are compatible with the GitHub API and are inherited from the
+// IssueServiceImpl, only the lookup of pull requests by commit differs.
+type GiteaIssueService struct {
+	IssueServiceImpl
+}
+
+// NewGiteaClient creates a new Client for the Gitea or Forgejo API at the given URL, e.g.
+// https://codeberg.org/api/v1/. Gitea supports neither commit comments nor minimizing
+// comments, so the Commit service and hiding previous comments are not available.
+func NewGiteaClient(ctx context.Context, url *url.URL, ts oauth2.TokenSource, client *http.Client) *Client {
+	tc := oauth2.NewClient(
+		context.WithValue(ctx, oauth2.HTTPClient, client),
+		ts,
+	)
+
+	c := github.NewClient(tc)
+	c.BaseURL = url
+
+	return &Client{
+		client: c,
+		ts:     ts,
+		Issue: &Issue{
+			client:      &GiteaIssueService{IssueServiceImpl{client: c}},
+			settleDelay: sectionSettleDelay,
+			Opt:         IssueOptions{},
+		},
+		Commit: &Commit{
+			Opt: CommitOptions{},
+		},
+	}
+}
+
+// ListPullRequestsWithCommit returns the pull request that contains the given commit, falling
+// back to the open pull requests with the commit as head.
+//
+//nolint:lll
+func (s *GiteaIssueService) ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%v/%v/commits/%v/pull", owner, repo, sha), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pull := new(github.PullRequest)
+
+	resp, err := s.client.Do(ctx, req, pull)
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+			log.Debug().Str("commit", sha).Msg("no pull request found for commit, searching open pull requests")
+
+			return s.listOpenPullRequestsWithHead(ctx, owner, repo, sha, opts)
+		}
+
+		return nil, resp, err
+	}
+
+	return []*github.PullRequest{pull}, resp, nil
+}
+
+// listOpenPullRequestsWithHead returns the open pull requests on the page with the commit as head.
+//
+//nolint:lll
+func (s *GiteaIssueService) listOpenPullRequestsWithHead(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+	pulls, resp, err := s.client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+		State:       "open",
+		ListOptions: *opts,
+	})
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to list open pull requests: %w", err)
+	}
+
+	var matches []*github.PullRequest
+
+	for _, pull := range pulls {
+		if pull.GetHead().GetSHA() == sha {
+			matches = append(matches, pull)
+		}
+	}
+
+	return matches, resp, nil
+}
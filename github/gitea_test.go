@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const giteaRepoPath = "/api/v1/repos/octocat/hello-world"
+
+func TestGiteaIssueService_ListPullRequestsWithCommit(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+giteaRepoPath+"/commits/abc123/pull", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number": 42}`))
+	})
+	mux.HandleFunc("GET "+giteaRepoPath+"/commits/{sha}/pull", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "pull request does not exist"}`))
+	})
+	mux.HandleFunc("GET "+giteaRepoPath+"/pulls", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		_, _ = w.Write([]byte(`[{"number": 7, "head": {"sha": "def456"}}, {"number": 8, "head": {"sha": "abc123"}}]`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL + "/api/v1/")
+	client := NewGiteaClient(context.Background(), baseURL, NewTokenSource("test-token"), server.Client())
+	client.Issue.Opt = IssueOptions{Owner: "octocat", Repo: "hello-world"}
+
+	numbers, err := client.Issue.FindPullRequests(context.Background(), "abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{42}, numbers)
+
+	// The commit lookup finds nothing, so the open pull requests are searched instead.
+	numbers, err = client.Issue.FindPullRequests(context.Background(), "def456")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{7}, numbers)
+
+	numbers, err = client.Issue.FindPullRequests(context.Background(), "ghi789")
+	assert.NoError(t, err)
+	assert.Empty(t, numbers)
+}
+
+func TestGiteaIssueService_FindComment(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+giteaRepoPath+"/issues/1/comments", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"id": 1, "body": "first comment", "user": {"login": "octocat"}},
+			{"id": 2, "body": "<!-- id: test-key -->", "user": {"login": "octocat"}}
+		]`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL + "/api/v1/")
+	client := NewGiteaClient(context.Background(), baseURL, NewTokenSource("test-token"), server.Client())
+	client.Issue.Opt = IssueOptions{Owner: "octocat", Repo: "hello-world", Number: 1, Key: "test-key"}
+
+	comment, err := client.Issue.FindComment(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), comment.GetID())
+}
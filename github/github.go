@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v67/github"
 	"golang.org/x/oauth2"
@@ -14,30 +15,72 @@ import (
 
 var ErrCommentNotFound = errors.New("comment not found")
 
+const (
+	ForgeGitHub  = "github"
+	ForgeGitea   = "gitea"
+	ForgeForgejo = "forgejo"
+	ForgeGitLab  = "gitlab"
+)
+
+// Action describes the outcome of adding, finding or deleting a comment.
+type Action string
+
+const (
+	ActionCreated   Action = "created"
+	ActionUpdated   Action = "updated"
+	ActionUnchanged Action = "unchanged"
+	ActionDeleted   Action = "deleted"
+	ActionSkipped   Action = "skipped"
+	ActionFound     Action = "found"
+)
+
 type Client struct {
-	client *github.Client
-	Issue  *Issue
+	client   *github.Client
+	ts       oauth2.TokenSource
+	identity identity
+	login    string
+	Issue    *Issue
+	Commit   *Commit
+}
+
+// identity is implemented by token sources that know the login of the identity
+// they authenticate, e.g. the bot user of a GitHub App.
+type identity interface {
+	Login(ctx context.Context) (string, error)
 }
 
 type Issue struct {
-	client IssueService
-	Opt    IssueOptions
+	client      IssueService
+	graphql     GraphQLService
+	gists       GistService
+	settleDelay time.Duration
+	maxLength   int
+	Opt         IssueOptions
 }
 
 type IssueOptions struct {
-	Number  int
-	Message string
-	Key     string
-	Repo    string
-	Owner   string
-	Update  bool
+	Number         int
+	Message        string
+	Key            string
+	Repo           string
+	Owner          string
+	Update         bool
+	UpdateMode     string
+	MaxEntries     int
+	Section        string
+	Authors        []string
+	Metadata       Metadata
+	LegacyKey      string
+	HidePrevious   bool
+	HideClassifier string
+	Overflow       string
+	OverflowURL    string
 }
 
 // NewGitHubClient creates a new GitHubClient instance that wraps the provided GitHub API client.
 // The GitHubClient provides a higher-level interface for interacting with the GitHub API,
 // including methods for managing GitHub issues.
-func NewClient(ctx context.Context, url *url.URL, token string, client *http.Client) *Client {
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+func NewClient(ctx context.Context, url *url.URL, ts oauth2.TokenSource, client *http.Client) *Client {
 	tc := oauth2.NewClient(
 		context.WithValue(ctx, oauth2.HTTPClient, client),
 		ts,
@@ -48,57 +91,326 @@ func NewClient(ctx context.Context, url *url.URL, token string, client *http.Cli
 
 	return &Client{
 		client: c,
+		ts:     ts,
 		Issue: &Issue{
-			client: &IssueServiceImpl{client: c},
-			Opt:    IssueOptions{},
+			client:      &IssueServiceImpl{client: c},
+			graphql:     NewGraphQLClient(tc, url),
+			gists:       &GistServiceImpl{client: c},
+			settleDelay: sectionSettleDelay,
+			Opt:         IssueOptions{},
+		},
+		Commit: &Commit{
+			client: &CommitServiceImpl{client: c},
+			gists:  &GistServiceImpl{client: c},
+			Opt:    CommitOptions{},
 		},
 	}
 }
 
+// Login returns the login of the authenticated user, or the bot user for GitHub App
+// installations. The login is resolved once and cached for subsequent calls.
+func (c *Client) Login(ctx context.Context) (string, error) {
+	if c.login != "" {
+		return c.login, nil
+	}
+
+	id, ok := c.ts.(identity)
+	if c.identity != nil {
+		id, ok = c.identity, true
+	}
+
+	if ok {
+		login, err := id.Login(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		c.login = login
+
+		return c.login, nil
+	}
+
+	user, _, err := c.client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get authenticated user: %w", err)
+	}
+
+	c.login = user.GetLogin()
+
+	return c.login, nil
+}
+
 // AddComment adds a new comment or updates an existing comment on a GitHub issue.
-// If the Update field is true, it will append a unique identifier to the comment
-// body and attempt to find and update the existing comment with that identifier.
-// Otherwise, it will create a new comment on the issue.
-func (i *Issue) AddComment(ctx context.Context) (*github.IssueComment, error) {
-	issueComment := &github.IssueComment{
-		Body: &i.Opt.Message,
+// If the Update field is true, it will attempt to find and update the existing comment
+// with the key. Otherwise, it will create a new comment on the issue.
+func (i *Issue) AddComment(ctx context.Context) (*github.IssueComment, Action, error) {
+	if i.Opt.Section != "" {
+		return i.AddSection(ctx)
 	}
 
-	if i.Opt.Update {
-		// Append plugin comment ID to comment message so we can search for it later
-		*issueComment.Body = fmt.Sprintf("%s\n<!-- id: %s -->\n", i.Opt.Message, i.Opt.Key)
+	var existing *github.IssueComment
 
-		comment, err := i.FindComment(ctx)
+	content := i.Opt.Message
+
+	if i.Opt.HidePrevious {
+		if err := i.HideComments(ctx); err != nil {
+			return nil, "", err
+		}
+	} else if i.Opt.Update {
+		var err error
+
+		existing, err = i.FindComment(ctx)
 		if err != nil && !errors.Is(err, ErrCommentNotFound) {
-			return nil, err
+			return nil, "", err
+		}
+
+		if existing != nil {
+			content = mergeEntries(existing.GetBody(), i.Opt.Message, i.Opt.UpdateMode, i.Opt.MaxEntries)
+		}
+	}
+
+	meta := i.Opt.Metadata
+
+	if i.Opt.Overflow == OverflowGist {
+		var err error
+
+		description := fmt.Sprintf("%s/%s#%d", i.Opt.Owner, i.Opt.Repo, i.Opt.Number)
+
+		if content, err = uploadGist(ctx, i.gists, content, i.Opt.Key, description, existing.GetBody(), &meta); err != nil {
+			return nil, "", err
+		}
+	}
+
+	limit := contentLimit(i.maxCommentLength(), i.Opt.Key, meta)
+
+	parts, err := fitContent(content, limit, i.Opt.Overflow, i.Opt.OverflowURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	comment, action, err := i.writeComment(ctx, existing, parts[0], meta)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(parts) > 1 || (i.Opt.Overflow == OverflowSplit && existing != nil) {
+		changed, err := i.writeParts(ctx, parts[1:])
+		if err != nil {
+			return nil, "", err
+		}
+
+		if changed && action == ActionUnchanged {
+			action = ActionUpdated
 		}
+	}
 
-		if comment != nil {
-			comment, _, err = i.client.EditComment(ctx, i.Opt.Owner, i.Opt.Repo, *comment.ID, issueComment)
+	return comment, action, nil
+}
+
+// writeComment updates the given existing comment with the content, or creates a new comment
+// if there is no existing comment.
+func (i *Issue) writeComment(
+	ctx context.Context, existing *github.IssueComment, content string, meta Metadata,
+) (*github.IssueComment, Action, error) {
+	// Append plugin metadata to comment message so we can search for it later
+	issueComment := &github.IssueComment{
+		Body: github.String(withMetadata(content, i.Opt.Key, meta)),
+	}
 
-			return comment, err
+	if existing != nil {
+		// Comments found by the legacy key are migrated to the new key.
+		if sameContent(existing.GetBody(), content) && hasKey(existing.GetBody(), i.Opt.Key) {
+			return existing, ActionUnchanged, nil
 		}
+
+		comment, _, err := i.client.EditComment(ctx, i.Opt.Owner, i.Opt.Repo, existing.GetID(), issueComment)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return comment, ActionUpdated, nil
 	}
 
 	comment, _, err := i.client.CreateComment(ctx, i.Opt.Owner, i.Opt.Repo, i.Opt.Number, issueComment)
+	if err != nil {
+		return nil, "", err
+	}
 
-	return comment, err
+	return comment, ActionCreated, nil
 }
 
-// FindComment returns the GitHub issue comment that contains the specified key, or nil if no such comment exists.
-// It retrieves all comments on the issue and searches for one that contains the specified key in the comment body.
+// DeleteComment deletes the GitHub issue comment that contains the specified key and its
+// continuation comments, or returns ErrCommentNotFound if no such comment exists.
+func (i *Issue) DeleteComment(ctx context.Context) (*github.IssueComment, error) {
+	if i.Opt.Section != "" {
+		return i.DeleteSection(ctx)
+	}
+
+	comment, err := i.FindComment(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := i.client.DeleteComment(ctx, i.Opt.Owner, i.Opt.Repo, comment.GetID()); err != nil {
+		return nil, err
+	}
+
+	if err := i.deleteParts(ctx); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// FindComment returns the newest GitHub issue comment that contains the specified key or, as fallback,
+// the LegacyKey, or nil if no such comment exists.
 func (i *Issue) FindComment(ctx context.Context) (*github.IssueComment, error) {
-	var allComments []*github.IssueComment
+	var match, legacy *github.IssueComment
+
+	isKey := func(key string) bool {
+		return key == i.Opt.Key || (i.Opt.LegacyKey != "" && key == i.Opt.LegacyKey)
+	}
+
+	err := i.scanKeyed(ctx, isKey, func(comment *github.IssueComment) bool {
+		if !hasKey(comment.GetBody(), i.Opt.Key) {
+			if legacy == nil {
+				legacy = comment
+			}
+
+			return true
+		}
+
+		match = comment
+
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if match == nil {
+		match = legacy
+	}
+
+	if match == nil {
+		return nil, fmt.Errorf("%w: failed to find comment with key %s", ErrCommentNotFound, i.Opt.Key)
+	}
+
+	return match, nil
+}
+
+// FindComments returns all GitHub issue comments that contain the specified key in the comment body,
+// ordered from the newest to the oldest comment.
+func (i *Issue) FindComments(ctx context.Context) ([]*github.IssueComment, error) {
+	var matches []*github.IssueComment
+
+	err := i.scanComments(ctx, func(comment *github.IssueComment) bool {
+		matches = append(matches, comment)
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// scanComments calls visit for all comments that contain the specified key, from the newest to the
+// oldest comment, until visit returns false. If the Authors field is set, only comments of these users
+// are considered.
+func (i *Issue) scanComments(ctx context.Context, visit func(*github.IssueComment) bool) error {
+	return i.scanKeyed(ctx, func(key string) bool { return key == i.Opt.Key }, visit)
+}
+
+// scanKeyed calls visit for all comments with a key that is accepted by match, from the newest
+// to the oldest comment, until visit returns false.
+func (i *Issue) scanKeyed(ctx context.Context, match func(string) bool, visit func(*github.IssueComment) bool) error {
+	list := func(page int) ([]*github.IssueComment, *github.Response, error) {
+		opts := &github.IssueListCommentsOptions{
+			ListOptions: github.ListOptions{Page: page, PerPage: commentsPerPage},
+		}
+
+		return i.client.ListComments(ctx, i.Opt.Owner, i.Opt.Repo, i.Opt.Number, opts)
+	}
+
+	return scanNewestFirst(list, func(comment *github.IssueComment) bool {
+		if !isAuthor(comment.GetUser(), i.Opt.Authors) {
+			return true
+		}
 
-	opts := &github.IssueListCommentsOptions{}
+		if meta, ok := parseMetadata(comment.GetBody()); !ok || !match(meta.Key) {
+			return true
+		}
+
+		return visit(comment)
+	})
+}
+
+// HideComments minimizes all GitHub issue comments that contain the specified key or the legacy key
+// and their continuation comments using the configured classifier. Comments that are already minimized
+// are skipped.
+func (i *Issue) HideComments(ctx context.Context) error {
+	var comments []*github.IssueComment
+
+	isKeyOrPart := func(key string) bool {
+		_, ok := parsePartKey(key, i.Opt.Key)
+
+		return ok || key == i.Opt.Key || (i.Opt.LegacyKey != "" && key == i.Opt.LegacyKey)
+	}
+
+	err := i.scanKeyed(ctx, isKeyOrPart, func(comment *github.IssueComment) bool {
+		comments = append(comments, comment)
+
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.GetNodeID())
+	}
+
+	minimized, err := i.graphql.MinimizedComments(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if minimized[id] {
+			continue
+		}
+
+		if err := i.graphql.MinimizeComment(ctx, id, i.Opt.HideClassifier); err != nil {
+			return fmt.Errorf("failed to minimize comment %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// FindPullRequests returns the numbers of all pull requests associated with the given commit SHA.
+// It retrieves all pages of the result and keeps the order returned by the GitHub API.
+func (i *Issue) FindPullRequests(ctx context.Context, sha string) ([]int, error) {
+	var numbers []int
+
+	opts := &github.ListOptions{}
 
 	for {
-		comments, resp, err := i.client.ListComments(ctx, i.Opt.Owner, i.Opt.Repo, i.Opt.Number, opts)
+		pulls, resp, err := i.client.ListPullRequestsWithCommit(ctx, i.Opt.Owner, i.Opt.Repo, sha, opts)
 		if err != nil {
 			return nil, err
 		}
 
-		allComments = append(allComments, comments...)
+		for _, pull := range pulls {
+			numbers = append(numbers, pull.GetNumber())
+		}
 
 		if resp == nil || resp.NextPage == 0 {
 			break
@@ -107,11 +419,26 @@ func (i *Issue) FindComment(ctx context.Context) (*github.IssueComment, error) {
 		opts.Page = resp.NextPage
 	}
 
-	for _, comment := range allComments {
-		if strings.Contains(*comment.Body, fmt.Sprintf("<!-- id: %s -->", i.Opt.Key)) {
-			return comment, nil
+	return numbers, nil
+}
+
+// NewTokenSource returns a token source for a static personal access token.
+func NewTokenSource(token string) oauth2.TokenSource {
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+}
+
+// isAuthor reports whether the user is one of the given authors. Any user is accepted
+// if no authors are given.
+func isAuthor(user *github.User, authors []string) bool {
+	if len(authors) == 0 {
+		return true
+	}
+
+	for _, author := range authors {
+		if strings.EqualFold(user.GetLogin(), author) {
+			return true
 		}
 	}
 
-	return nil, fmt.Errorf("%w: failed to find comment with key %s", ErrCommentNotFound, i.Opt.Key)
+	return false
 }
@@ -71,6 +71,34 @@ func TestGithubIssue_FindComment(t *testing.T) {
 			},
 			want: &github.IssueComment{Body: github.String("<!-- id: test-key -->\ntest comment\n")},
 		},
+		{
+			name: "legacy comment found",
+			issueOpt: IssueOptions{
+				Key:       "test-key",
+				LegacyKey: "legacy-key",
+				Owner:     "test-owner",
+				Repo:      "test-repo",
+			},
+			comments: []*github.IssueComment{
+				{Body: github.String("<!-- id: legacy-key -->\nold comment\n")},
+				{Body: github.String("<!-- id: legacy-key -->\nlegacy comment\n")},
+			},
+			want: &github.IssueComment{Body: github.String("<!-- id: legacy-key -->\nlegacy comment\n")},
+		},
+		{
+			name: "key preferred over legacy key",
+			issueOpt: IssueOptions{
+				Key:       "test-key",
+				LegacyKey: "legacy-key",
+				Owner:     "test-owner",
+				Repo:      "test-repo",
+			},
+			comments: []*github.IssueComment{
+				{Body: github.String("<!-- id: test-key -->\ntest comment\n")},
+				{Body: github.String("<!-- id: legacy-key -->\nlegacy comment\n")},
+			},
+			want: &github.IssueComment{Body: github.String("<!-- id: test-key -->\ntest comment\n")},
+		},
 	}
 
 	for _, tt := range tests {
@@ -101,11 +129,12 @@ func TestGithubIssue_FindComment(t *testing.T) {
 
 func TestGithubIssue_AddComment(t *testing.T) {
 	tests := []struct {
-		name     string
-		issueOpt IssueOptions
-		comments []*github.IssueComment
-		want     *github.IssueComment
-		wantErr  error
+		name       string
+		issueOpt   IssueOptions
+		comments   []*github.IssueComment
+		want       *github.IssueComment
+		wantAction Action
+		wantErr    error
 	}{
 		{
 			name: "create new comment",
@@ -119,6 +148,7 @@ func TestGithubIssue_AddComment(t *testing.T) {
 			want: &github.IssueComment{
 				Body: github.String("<!-- id: test-key -->\ntest message\n"),
 			},
+			wantAction: ActionCreated,
 		},
 		{
 			name: "update existing comment",
@@ -135,6 +165,43 @@ func TestGithubIssue_AddComment(t *testing.T) {
 			want: &github.IssueComment{
 				Body: github.String("<!-- id: test-key -->\ntest message\n"),
 			},
+			wantAction: ActionUpdated,
+		},
+		{
+			name: "skip unchanged comment",
+			issueOpt: IssueOptions{
+				Key:     "test-key",
+				Owner:   "test-owner",
+				Repo:    "test-repo",
+				Message: "test message",
+				Update:  true,
+			},
+			comments: []*github.IssueComment{
+				{ID: github.Int64(123), Body: github.String(withMetadata("test message", "test-key", Metadata{Pipeline: 1}))},
+			},
+			want: &github.IssueComment{
+				ID:   github.Int64(123),
+				Body: github.String(withMetadata("test message", "test-key", Metadata{Pipeline: 1})),
+			},
+			wantAction: ActionUnchanged,
+		},
+		{
+			name: "migrate unchanged legacy comment",
+			issueOpt: IssueOptions{
+				Key:       "test-key",
+				LegacyKey: "legacy-key",
+				Owner:     "test-owner",
+				Repo:      "test-repo",
+				Message:   "test message",
+				Update:    true,
+			},
+			comments: []*github.IssueComment{
+				{ID: github.Int64(123), Body: github.String(withMetadata("test message", "legacy-key", Metadata{}))},
+			},
+			want: &github.IssueComment{
+				Body: github.String("<!-- id: test-key -->\ntest message\n"),
+			},
+			wantAction: ActionUpdated,
 		},
 		{
 			name: "update non-existing comment",
@@ -148,6 +215,7 @@ func TestGithubIssue_AddComment(t *testing.T) {
 			want: &github.IssueComment{
 				Body: github.String("<!-- id: test-key -->\ntest message\n"),
 			},
+			wantAction: ActionCreated,
 		},
 		{
 			name: "create new comment with error",
@@ -176,7 +244,7 @@ func TestGithubIssue_AddComment(t *testing.T) {
 					Return(tt.comments, nil, nil)
 			}
 
-			if tt.issueOpt.Update && tt.comments != nil {
+			if tt.wantAction == ActionUpdated {
 				mockClient.
 					On("EditComment", mock.Anything, tt.issueOpt.Owner, tt.issueOpt.Repo, mock.Anything, mock.Anything).
 					Return(&github.IssueComment{
@@ -197,7 +265,7 @@ func TestGithubIssue_AddComment(t *testing.T) {
 					Return(comment, nil, tt.wantErr)
 			}
 
-			got, err := issue.AddComment(context.Background())
+			got, action, err := issue.AddComment(context.Background())
 			if tt.wantErr != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tt.want, got)
@@ -207,6 +275,244 @@ func TestGithubIssue_AddComment(t *testing.T) {
 
 			assert.NoError(t, err)
 			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantAction, action)
+		})
+	}
+}
+
+func TestGithubIssue_FindPullRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		pulls   []*github.PullRequest
+		want    []int
+		wantErr error
+	}{
+		{
+			name: "no pull requests",
+		},
+		{
+			name: "multiple pull requests",
+			pulls: []*github.PullRequest{
+				{Number: github.Int(12)},
+				{Number: github.Int(7)},
+			},
+			want: []int{12, 7},
+		},
+		{
+			name:    "list pull requests with error",
+			wantErr: ErrInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := mocks.NewMockIssueService(t)
+			issue := &Issue{
+				client: mockClient,
+				Opt: IssueOptions{
+					Owner: "test-owner",
+					Repo:  "test-repo",
+				},
+			}
+
+			mockClient.
+				On("ListPullRequestsWithCommit", mock.Anything, "test-owner", "test-repo", "test-sha", mock.Anything).
+				Return(tt.pulls, nil, tt.wantErr)
+
+			got, err := issue.FindPullRequests(context.Background(), "test-sha")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGithubIssue_DeleteComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []*github.IssueComment
+		want     *github.IssueComment
+		wantErr  error
+	}{
+		{
+			name: "delete existing comment",
+			comments: []*github.IssueComment{
+				{ID: github.Int64(1), Body: github.String("other comment")},
+				{ID: github.Int64(123), Body: github.String("test message\n<!-- id: test-key -->\n")},
+			},
+			want: &github.IssueComment{ID: github.Int64(123), Body: github.String("test message\n<!-- id: test-key -->\n")},
+		},
+		{
+			name: "delete non-existing comment",
+			comments: []*github.IssueComment{
+				{ID: github.Int64(1), Body: github.String("other comment")},
+			},
+			wantErr: ErrCommentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := mocks.NewMockIssueService(t)
+			issue := &Issue{
+				client: mockClient,
+				Opt: IssueOptions{
+					Key:   "test-key",
+					Owner: "test-owner",
+					Repo:  "test-repo",
+				},
+			}
+
+			mockClient.
+				On("ListComments", mock.Anything, "test-owner", "test-repo", mock.Anything, mock.Anything).
+				Return(tt.comments, nil, nil)
+
+			if tt.want != nil {
+				mockClient.
+					On("DeleteComment", mock.Anything, "test-owner", "test-repo", tt.want.GetID()).
+					Return(nil, nil)
+			}
+
+			got, err := issue.DeleteComment(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGithubIssue_HideComments(t *testing.T) {
+	mockClient := mocks.NewMockIssueService(t)
+	mockGraphQL := mocks.NewMockGraphQLService(t)
+	issue := &Issue{
+		client:  mockClient,
+		graphql: mockGraphQL,
+		Opt: IssueOptions{
+			Key:            "test-key",
+			LegacyKey:      "legacy-key",
+			Owner:          "test-owner",
+			Repo:           "test-repo",
+			HideClassifier: "OUTDATED",
+		},
+	}
+
+	mockClient.
+		On("ListComments", mock.Anything, "test-owner", "test-repo", mock.Anything, mock.Anything).
+		Return([]*github.IssueComment{
+			{NodeID: github.String("IC_1"), Body: github.String("test message\n<!-- id: test-key -->\n")},
+			{NodeID: github.String("IC_2"), Body: github.String("other comment")},
+			{NodeID: github.String("IC_3"), Body: github.String("test message\n<!-- id: test-key -->\n")},
+			{NodeID: github.String("IC_4"), Body: github.String("legacy message\n<!-- id: legacy-key -->\n")},
+		}, nil, nil)
+	mockGraphQL.
+		On("MinimizedComments", mock.Anything, []string{"IC_4", "IC_3", "IC_1"}).
+		Return(map[string]bool{"IC_1": true, "IC_3": false, "IC_4": false}, nil)
+	mockGraphQL.
+		On("MinimizeComment", mock.Anything, "IC_3", "OUTDATED").
+		Return(nil)
+	mockGraphQL.
+		On("MinimizeComment", mock.Anything, "IC_4", "OUTDATED").
+		Return(nil)
+
+	assert.NoError(t, issue.HideComments(context.Background()))
+}
+
+func TestGithubIssue_FindComment_Pages(t *testing.T) {
+	tests := []struct {
+		name      string
+		authors   []string
+		want      int64
+		wantPages []int
+	}{
+		{
+			name:      "newest match on last page",
+			want:      5,
+			wantPages: []int{1, 3},
+		},
+		{
+			name:      "match of author",
+			authors:   []string{"bot"},
+			want:      3,
+			wantPages: []int{1, 3, 2},
+		},
+		{
+			name:      "no match of author",
+			authors:   []string{"other"},
+			wantPages: []int{1, 3, 2},
+		},
+		{
+			name:      "match of trusted author",
+			authors:   []string{"bot", "USER"},
+			want:      5,
+			wantPages: []int{1, 3},
+		},
+	}
+
+	pages := map[int][]*github.IssueComment{
+		1: {
+			{ID: github.Int64(1), User: &github.User{Login: github.String("bot")}, Body: github.String("<!-- id: test-key -->")},
+		},
+		2: {
+			{ID: github.Int64(2), User: &github.User{Login: github.String("user")}, Body: github.String("other comment")},
+			{ID: github.Int64(3), User: &github.User{Login: github.String("bot")}, Body: github.String("<!-- id: test-key -->")},
+		},
+		3: {
+			{ID: github.Int64(4), User: &github.User{Login: github.String("bot")}, Body: github.String("other comment")},
+			{
+				ID:   github.Int64(5),
+				User: &github.User{Login: github.String("user")},
+				Body: github.String("<!-- id: test-key -->"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPages []int
+
+			mockClient := mocks.NewMockIssueService(t)
+			issue := &Issue{
+				client: mockClient,
+				Opt: IssueOptions{
+					Key:     "test-key",
+					Owner:   "test-owner",
+					Repo:    "test-repo",
+					Authors: tt.authors,
+				},
+			}
+
+			mockClient.
+				On("ListComments", mock.Anything, "test-owner", "test-repo", mock.Anything, mock.Anything).
+				Return(func(_ context.Context, _, _ string, _ int, opts *github.IssueListCommentsOptions) (
+					[]*github.IssueComment, *github.Response, error,
+				) {
+					assert.Equal(t, 100, opts.PerPage)
+					gotPages = append(gotPages, opts.Page)
+
+					return pages[opts.Page], &github.Response{LastPage: 3}, nil
+				})
+
+			got, err := issue.FindComment(context.Background())
+			assert.Equal(t, tt.wantPages, gotPages)
+
+			if tt.want == 0 {
+				assert.ErrorIs(t, err, ErrCommentNotFound)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.GetID())
 		})
 	}
 }
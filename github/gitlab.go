@@ -0,0 +1,239 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+	"golang.org/x/oauth2"
+)
+
+var ErrNoteNotFound = errors.New("merge request of note not found")
+
+// GitLabIssueService implements the IssueService for merge request notes of the GitLab API.
+// The issue number is the internal ID (IID) of the merge request.
+type GitLabIssueService struct {
+	client *github.Client
+	webURL string
+	notes  map[int64]int
+}
+
+type gitLabNote struct {
+	ID     int64  `json:"id"`
+	Body   string `json:"body"`
+	System bool   `json:"system"`
+	Author struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+type gitLabMergeRequest struct {
+	IID int `json:"iid"`
+}
+
+type gitLabUser struct {
+	Username string `json:"username"`
+}
+
+// NewGitLabClient creates a new Client for the GitLab API at the given URL, e.g.
+// https://gitlab.com/api/v4/. GitLab supports neither editable commit comments nor minimizing
+// notes, so the Commit service and hiding previous comments are not available.
+func NewGitLabClient(ctx context.Context, url *url.URL, ts oauth2.TokenSource, client *http.Client) *Client {
+	tc := oauth2.NewClient(
+		context.WithValue(ctx, oauth2.HTTPClient, client),
+		ts,
+	)
+
+	c := github.NewClient(tc)
+	c.BaseURL = url
+
+	service := &GitLabIssueService{
+		client: c,
+		webURL: strings.TrimSuffix(url.String(), "api/v4/"),
+		notes:  make(map[int64]int),
+	}
+
+	return &Client{
+		client:   c,
+		ts:       ts,
+		identity: service,
+		Issue: &Issue{
+			client:      service,
+			settleDelay: sectionSettleDelay,
+			maxLength:   MaxGitLabCommentLength,
+			Opt:         IssueOptions{},
+		},
+		Commit: &Commit{
+			Opt: CommitOptions{},
+		},
+	}
+}
+
+// CreateComment creates a note on the merge request.
+//
+//nolint:lll
+func (s *GitLabIssueService) CreateComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	note := new(gitLabNote)
+
+	resp, err := s.do(ctx, http.MethodPost, s.notesPath(owner, repo, number), comment, note)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return s.issueComment(owner, repo, number, note), resp, nil
+}
+
+// EditComment updates the body of a note that was listed or created before.
+//
+//nolint:lll
+func (s *GitLabIssueService) EditComment(ctx context.Context, owner, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	number, ok := s.notes[commentID]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %d", ErrNoteNotFound, commentID)
+	}
+
+	note := new(gitLabNote)
+	path := fmt.Sprintf("%s/%d", s.notesPath(owner, repo, number), commentID)
+
+	resp, err := s.do(ctx, http.MethodPut, path, comment, note)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return s.issueComment(owner, repo, number, note), resp, nil
+}
+
+// ListComments lists the notes of the merge request from the oldest to the newest, without system notes.
+//
+//nolint:lll
+func (s *GitLabIssueService) ListComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	query := listQuery(opts.ListOptions)
+	query.Set("sort", "asc")
+	query.Set("order_by", "created_at")
+
+	path := s.notesPath(owner, repo, number) + "?" + query.Encode()
+
+	var notes []*gitLabNote
+
+	resp, err := s.do(ctx, http.MethodGet, path, nil, &notes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	comments := make([]*github.IssueComment, 0, len(notes))
+
+	for _, note := range notes {
+		if note.System {
+			continue
+		}
+
+		comments = append(comments, s.issueComment(owner, repo, number, note))
+	}
+
+	return comments, resp, nil
+}
+
+// DeleteComment deletes a note that was listed or created before.
+//
+//nolint:lll
+func (s *GitLabIssueService) DeleteComment(ctx context.Context, owner, repo string, commentID int64) (*github.Response, error) {
+	number, ok := s.notes[commentID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrNoteNotFound, commentID)
+	}
+
+	resp, err := s.do(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", s.notesPath(owner, repo, number), commentID), nil, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	delete(s.notes, commentID)
+
+	return resp, nil
+}
+
+// ListPullRequestsWithCommit lists the merge requests that contain the given commit.
+//
+//nolint:lll
+func (s *GitLabIssueService) ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+	path := fmt.Sprintf("%s/repository/commits/%s/merge_requests?%s",
+		projectPath(owner, repo), url.PathEscape(sha), listQuery(*opts).Encode())
+
+	var mergeRequests []*gitLabMergeRequest
+
+	resp, err := s.do(ctx, http.MethodGet, path, nil, &mergeRequests)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	pulls := make([]*github.PullRequest, 0, len(mergeRequests))
+	for _, mr := range mergeRequests {
+		pulls = append(pulls, &github.PullRequest{Number: github.Int(mr.IID)})
+	}
+
+	return pulls, resp, nil
+}
+
+// Login returns the username of the authenticated user.
+func (s *GitLabIssueService) Login(ctx context.Context) (string, error) {
+	user := new(gitLabUser)
+
+	if _, err := s.do(ctx, http.MethodGet, "user", nil, user); err != nil {
+		return "", fmt.Errorf("failed to get authenticated user: %w", err)
+	}
+
+	return user.Username, nil
+}
+
+// do sends an API request with the given body and decodes the response into v.
+func (s *GitLabIssueService) do(ctx context.Context, method, path string, body, v any) (*github.Response, error) {
+	req, err := s.client.NewRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, v)
+}
+
+// notesPath returns the API path of the notes of the given merge request.
+func (s *GitLabIssueService) notesPath(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/merge_requests/%d/notes", projectPath(owner, repo), number)
+}
+
+// issueComment converts the note to an issue comment and records its merge request.
+func (s *GitLabIssueService) issueComment(owner, repo string, number int, note *gitLabNote) *github.IssueComment {
+	s.notes[note.ID] = number
+
+	return &github.IssueComment{
+		ID:      github.Int64(note.ID),
+		Body:    github.String(note.Body),
+		HTMLURL: github.String(fmt.Sprintf("%s%s/%s/-/merge_requests/%d#note_%d", s.webURL, owner, repo, number, note.ID)),
+		User:    &github.User{Login: github.String(note.Author.Username)},
+	}
+}
+
+// projectPath returns the API path of the project. Nested groups are part of the owner and
+// the full project path is escaped to a single path segment as required by the GitLab API.
+func projectPath(owner, repo string) string {
+	return "projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+// listQuery returns the pagination query parameters of the list options.
+func listQuery(opts github.ListOptions) url.Values {
+	query := url.Values{}
+
+	if opts.Page > 0 {
+		query.Set("page", strconv.Itoa(opts.Page))
+	}
+
+	if opts.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+
+	return query
+}
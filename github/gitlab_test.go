@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitLabIssueService_AddComment(t *testing.T) {
+	body := withMetadata("old message", "test-key", Metadata{})
+
+	notesPath := "/api/v4/projects/{project}/merge_requests/1/notes"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/user", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"username": "octocat"}`))
+	})
+	mux.HandleFunc("GET "+notesPath, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "group/octocat/hello-world", r.PathValue("project"))
+		assert.Equal(t, "asc", r.URL.Query().Get("sort"))
+
+		notes, _ := json.Marshal([]map[string]any{
+			{"id": 1, "body": body, "author": map[string]any{"username": "other"}},
+			{"id": 2, "body": body, "author": map[string]any{"username": "octocat"}},
+			{"id": 3, "body": "added 1 commit", "system": true},
+		})
+		_, _ = w.Write(notes)
+	})
+	mux.HandleFunc("PUT "+notesPath+"/2", func(w http.ResponseWriter, r *http.Request) {
+		note := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&note))
+
+		note["id"] = 2
+		note["author"] = map[string]any{"username": "octocat"}
+
+		data, _ := json.Marshal(note)
+		_, _ = w.Write(data)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL + "/api/v4/")
+	client := NewGitLabClient(context.Background(), baseURL, NewTokenSource("test-token"), server.Client())
+
+	login, err := client.Login(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "octocat", login)
+
+	client.Issue.Opt = IssueOptions{
+		Owner:   "group/octocat",
+		Repo:    "hello-world",
+		Number:  1,
+		Key:     "test-key",
+		Message: "new message",
+		Update:  true,
+		Authors: []string{login},
+	}
+
+	comment, action, err := client.Issue.AddComment(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ActionUpdated, action)
+	assert.Equal(t, int64(2), comment.GetID())
+	assert.Equal(t, server.URL+"/group/octocat/hello-world/-/merge_requests/1#note_2", comment.GetHTMLURL())
+	assert.Equal(t, "new message", stripMetadata(comment.GetBody()))
+}
+
+func TestGitLabIssueService_EditComment(t *testing.T) {
+	service := &GitLabIssueService{notes: map[int64]int{}}
+
+	_, _, err := service.EditComment(context.Background(), "octocat", "hello-world", 1, nil)
+	assert.ErrorIs(t, err, ErrNoteNotFound)
+}
+
+func TestGitLabIssueService_FindPullRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	path := "GET /api/v4/projects/{project}/repository/commits/abc123/merge_requests"
+	mux.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"iid": 4}, {"iid": 2}]`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL + "/api/v4/")
+	client := NewGitLabClient(context.Background(), baseURL, NewTokenSource("test-token"), server.Client())
+	client.Issue.Opt = IssueOptions{Owner: "octocat", Repo: "hello-world"}
+
+	numbers, err := client.Issue.FindPullRequests(context.Background(), "abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 2}, numbers)
+}
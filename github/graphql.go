@@ -0,0 +1,157 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var ErrGraphQL = errors.New("graphql request failed")
+
+const (
+	// maxNodesPerQuery is the maximum number of node IDs accepted by the nodes query.
+	maxNodesPerQuery = 100
+
+	queryMinimizedComments = `query($ids: [ID!]!) {
+  nodes(ids: $ids) {
+    ... on Minimizable {
+      isMinimized
+    }
+    ... on Node {
+      id
+    }
+  }
+}`
+
+	mutationMinimizeComment = `mutation($id: ID!, $classifier: ReportedContentClassifiers!) {
+  minimizeComment(input: {subjectId: $id, classifier: $classifier}) {
+    minimizedComment {
+      isMinimized
+    }
+  }
+}`
+)
+
+// GraphQLService is an interface that wraps the GitHub GraphQL API methods
+// that are not available in the REST API.
+type GraphQLService interface {
+	MinimizedComments(ctx context.Context, ids []string) (map[string]bool, error)
+	MinimizeComment(ctx context.Context, id, classifier string) error
+}
+
+// GraphQLClient is a minimal client for the GitHub GraphQL API.
+type GraphQLClient struct {
+	client *http.Client
+	url    *url.URL
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// NewGraphQLClient creates a new GraphQLClient for the GitHub instance of the given REST API URL.
+// The GraphQL endpoint is derived from the REST API URL, e.g. `https://api.github.com/graphql`
+// for GitHub.com and `https://example.com/api/graphql` for GitHub Enterprise Server.
+func NewGraphQLClient(client *http.Client, baseURL *url.URL) *GraphQLClient {
+	endpoint := *baseURL
+
+	if strings.HasSuffix(endpoint.Path, "/api/v3/") {
+		endpoint.Path = strings.TrimSuffix(endpoint.Path, "v3/") + "graphql"
+	} else {
+		endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + "/graphql"
+	}
+
+	return &GraphQLClient{
+		client: client,
+		url:    &endpoint,
+	}
+}
+
+// MinimizedComments returns the minimized state of the comments with the given node IDs.
+// The IDs are queried in batches, as the GraphQL API limits the number of nodes per query.
+func (c *GraphQLClient) MinimizedComments(ctx context.Context, ids []string) (map[string]bool, error) {
+	minimized := make(map[string]bool, len(ids))
+
+	for batch := range slices.Chunk(ids, maxNodesPerQuery) {
+		var data struct {
+			Nodes []struct {
+				ID          string `json:"id"`
+				IsMinimized bool   `json:"isMinimized"`
+			} `json:"nodes"`
+		}
+
+		if err := c.do(withReadOnly(ctx), queryMinimizedComments, map[string]any{"ids": batch}, &data); err != nil {
+			return nil, err
+		}
+
+		for _, node := range data.Nodes {
+			minimized[node.ID] = node.IsMinimized
+		}
+	}
+
+	return minimized, nil
+}
+
+// MinimizeComment hides the comment with the given node ID using the given classifier,
+// e.g. `OUTDATED` or `RESOLVED`.
+func (c *GraphQLClient) MinimizeComment(ctx context.Context, id, classifier string) error {
+	vars := map[string]any{
+		"id":         id,
+		"classifier": classifier,
+	}
+
+	return c.do(ctx, mutationMinimizeComment, vars, nil)
+}
+
+func (c *GraphQLClient) do(ctx context.Context, query string, vars map[string]any, data any) error {
+	payload, err := json.Marshal(graphQLRequest{Query: query, Variables: vars})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url.String(), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s %s: %s", ErrGraphQL, req.Method, req.URL, resp.Status)
+	}
+
+	var result graphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %w", ErrGraphQL, err)
+	}
+
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%w: %s", ErrGraphQL, result.Errors[0].Message)
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	return json.Unmarshal(result.Data, data)
+}
@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGraphQLClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+	}{
+		{
+			name:    "github",
+			baseURL: "https://api.github.com/",
+			want:    "https://api.github.com/graphql",
+		},
+		{
+			name:    "github enterprise",
+			baseURL: "https://github.example.com/api/v3/",
+			want:    "https://github.example.com/api/graphql",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, _ := url.Parse(tt.baseURL)
+			got := NewGraphQLClient(http.DefaultClient, baseURL)
+
+			assert.Equal(t, tt.want, got.url.String())
+		})
+	}
+}
+
+func TestGraphQLClient_MinimizeComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		wantErr  bool
+	}{
+		{
+			name:     "minimize comment",
+			response: `{"data": {"minimizeComment": {"minimizedComment": {"isMinimized": true}}}}`,
+		},
+		{
+			name:     "minimize comment with error",
+			response: `{"data": null, "errors": [{"message": "Could not resolve to a node"}]}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got graphQLRequest
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/graphql", r.URL.Path)
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			baseURL, _ := url.Parse(server.URL + "/")
+			client := NewGraphQLClient(server.Client(), baseURL)
+
+			err := client.MinimizeComment(context.Background(), "IC_test", "OUTDATED")
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrGraphQL)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "IC_test", got.Variables["id"])
+			assert.Equal(t, "OUTDATED", got.Variables["classifier"])
+		})
+	}
+}
+
+func TestGraphQLClient_MinimizedComments(t *testing.T) {
+	ids := make([]string, 0, 250)
+	for n := range 250 {
+		ids = append(ids, "IC_"+strconv.Itoa(n))
+	}
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				IDs []string `json:"ids"`
+			} `json:"variables"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.LessOrEqual(t, len(req.Variables.IDs), maxNodesPerQuery)
+
+		requests++
+
+		nodes := make([]map[string]any, 0, len(req.Variables.IDs))
+		for _, id := range req.Variables.IDs {
+			nodes = append(nodes, map[string]any{"id": id, "isMinimized": id == "IC_0" || id == "IC_249"})
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"nodes": nodes}})
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := NewGraphQLClient(server.Client(), baseURL)
+
+	got, err := client.MinimizedComments(context.Background(), ids)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, requests)
+	assert.Len(t, got, 250)
+	assert.True(t, got["IC_0"])
+	assert.True(t, got["IC_249"])
+	assert.False(t, got["IC_100"])
+}
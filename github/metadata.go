@@ -0,0 +1,133 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// MetadataVersion is the version of the metadata format written by this plugin.
+	MetadataVersion = 1
+
+	metadataPrefix = "<!-- wp-github-comment: "
+	legacyPrefix   = "<!-- id: "
+	commentSuffix  = " -->"
+)
+
+// Metadata is stored as hidden JSON block at the end of every comment written by the plugin.
+// It identifies the comment by its key and records the context of the last write.
+type Metadata struct {
+	Version       int       `json:"v"`
+	Key           string    `json:"key"`
+	Pipeline      int64     `json:"pipeline,omitempty"`
+	Commit        string    `json:"commit,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+	PluginVersion string    `json:"plugin_version,omitempty"`
+	// Hash is the SHA-256 checksum of the comment content without the metadata block.
+	Hash string `json:"hash,omitempty"`
+	// Gist is the ID of the gist that holds the full content of an oversized comment.
+	Gist string `json:"gist,omitempty"`
+	// GistHash is the SHA-256 checksum of the content uploaded to the gist.
+	GistHash string `json:"gist_hash,omitempty"`
+}
+
+// withMetadata appends the metadata block for the given key and content to the content.
+// The version, key and content hash of the given metadata are set accordingly.
+func withMetadata(content, key string, meta Metadata) string {
+	meta.Version = MetadataVersion
+	meta.Key = key
+	meta.Hash = contentHash(content)
+
+	// The JSON encoder escapes HTML characters, so the payload never contains `-->`.
+	payload, err := json.Marshal(meta)
+	if err != nil {
+		// Unreachable, the metadata only contains plain types.
+		payload = []byte(fmt.Sprintf(`{"v":%d,"key":%q}`, MetadataVersion, key))
+	}
+
+	return fmt.Sprintf("%s\n%s%s%s\n", content, metadataPrefix, payload, commentSuffix)
+}
+
+// parseMetadata returns the metadata of the given comment body. Comments written by older
+// versions of the plugin only contain a `<!-- id: KEY -->` marker, which is returned as
+// metadata with version 0 and the key set.
+func parseMetadata(body string) (Metadata, bool) {
+	_, meta, ok := splitMetadata(body)
+
+	return meta, ok
+}
+
+// stripMetadata removes the metadata block or legacy marker from the given comment body.
+func stripMetadata(body string) string {
+	content, _, _ := splitMetadata(body)
+
+	return content
+}
+
+// hasKey reports whether the metadata of the given comment body matches the key.
+func hasKey(body, key string) bool {
+	meta, ok := parseMetadata(body)
+
+	return ok && meta.Key == key
+}
+
+// sameContent reports whether the content of the given comment body equals the given content.
+// The content hash of the metadata block is compared if available, otherwise the content itself.
+func sameContent(body, content string) bool {
+	existing, meta, ok := splitMetadata(body)
+	if ok && meta.Hash != "" {
+		return meta.Hash == contentHash(content)
+	}
+
+	return existing == content
+}
+
+// contentHash returns the hex encoded SHA-256 checksum of the given comment content.
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func splitMetadata(body string) (string, Metadata, bool) {
+	if content, payload, ok := cutComment(body, metadataPrefix); ok {
+		var meta Metadata
+		if err := json.Unmarshal([]byte(payload), &meta); err == nil {
+			return content, meta, true
+		}
+	}
+
+	if content, key, ok := cutComment(body, legacyPrefix); ok {
+		return content, Metadata{Key: key}, true
+	}
+
+	return body, Metadata{}, false
+}
+
+// cutComment removes the last hidden HTML comment with the given prefix from the body and
+// returns the remaining body and the payload of the removed comment.
+func cutComment(body, prefix string) (string, string, bool) {
+	start := strings.LastIndex(body, prefix)
+	if start < 0 {
+		return body, "", false
+	}
+
+	end := strings.Index(body[start:], commentSuffix)
+	if end < 0 {
+		return body, "", false
+	}
+
+	payload := body[start+len(prefix) : start+end]
+	before, after := body[:start], body[start+end+len(commentSuffix):]
+
+	// The block is written on its own line at the end of the body.
+	if strings.TrimSpace(after) == "" {
+		return strings.TrimSuffix(before, "\n"), payload, true
+	}
+
+	return before + after, payload, true
+}
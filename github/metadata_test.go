@@ -0,0 +1,84 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata(t *testing.T) {
+	meta := Metadata{
+		Pipeline:      42,
+		Commit:        "abc123",
+		Timestamp:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		PluginVersion: "1.0.0",
+	}
+
+	body := withMetadata("test <b>message</b> -->", "test-key", meta)
+
+	got, ok := parseMetadata(body)
+	assert.True(t, ok)
+	assert.Equal(t, MetadataVersion, got.Version)
+	assert.Equal(t, "test-key", got.Key)
+	assert.Equal(t, int64(42), got.Pipeline)
+	assert.Equal(t, "abc123", got.Commit)
+	assert.Equal(t, meta.Timestamp, got.Timestamp)
+	assert.Equal(t, "1.0.0", got.PluginVersion)
+	assert.Equal(t, contentHash("test <b>message</b> -->"), got.Hash)
+
+	assert.Equal(t, "test <b>message</b> -->", stripMetadata(body))
+	assert.True(t, hasKey(body, "test-key"))
+	assert.False(t, hasKey(body, "other-key"))
+}
+
+func TestParseMetadata(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantKey     string
+		wantOK      bool
+		wantContent string
+	}{
+		{
+			name:        "legacy marker",
+			body:        "test message\n<!-- id: test-key -->\n",
+			wantKey:     "test-key",
+			wantOK:      true,
+			wantContent: "test message",
+		},
+		{
+			name:        "legacy marker at the start",
+			body:        "<!-- id: test-key -->\ntest message\n",
+			wantKey:     "test-key",
+			wantOK:      true,
+			wantContent: "\ntest message\n",
+		},
+		{
+			name:        "metadata block",
+			body:        "test message\n<!-- wp-github-comment: {\"v\":1,\"key\":\"test-key\"} -->\n",
+			wantKey:     "test-key",
+			wantOK:      true,
+			wantContent: "test message",
+		},
+		{
+			name:        "invalid metadata block",
+			body:        "test message\n<!-- wp-github-comment: {invalid} -->\n",
+			wantContent: "test message\n<!-- wp-github-comment: {invalid} -->\n",
+		},
+		{
+			name:        "no metadata",
+			body:        "test message",
+			wantContent: "test message",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseMetadata(tt.body)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantKey, got.Key)
+			assert.Equal(t, tt.wantContent, stripMetadata(tt.body))
+		})
+	}
+}
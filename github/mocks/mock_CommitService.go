@@ -0,0 +1,311 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	github "github.com/google/go-github/v67/github"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCommitService is an autogenerated mock type for the CommitService type
+type MockCommitService struct {
+	mock.Mock
+}
+
+type MockCommitService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCommitService) EXPECT() *MockCommitService_Expecter {
+	return &MockCommitService_Expecter{mock: &_m.Mock}
+}
+
+// CreateComment provides a mock function with given fields: ctx, owner, repo, sha, comment
+func (_m *MockCommitService) CreateComment(ctx context.Context, owner string, repo string, sha string, comment *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, sha, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateComment")
+	}
+
+	var r0 *github.RepositoryComment
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, sha, comment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *github.RepositoryComment) *github.RepositoryComment); ok {
+		r0 = rf(ctx, owner, repo, sha, comment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.RepositoryComment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *github.RepositoryComment) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, sha, comment)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, *github.RepositoryComment) error); ok {
+		r2 = rf(ctx, owner, repo, sha, comment)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockCommitService_CreateComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateComment'
+type MockCommitService_CreateComment_Call struct {
+	*mock.Call
+}
+
+// CreateComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - sha string
+//   - comment *github.RepositoryComment
+func (_e *MockCommitService_Expecter) CreateComment(ctx interface{}, owner interface{}, repo interface{}, sha interface{}, comment interface{}) *MockCommitService_CreateComment_Call {
+	return &MockCommitService_CreateComment_Call{Call: _e.mock.On("CreateComment", ctx, owner, repo, sha, comment)}
+}
+
+func (_c *MockCommitService_CreateComment_Call) Run(run func(ctx context.Context, owner string, repo string, sha string, comment *github.RepositoryComment)) *MockCommitService_CreateComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(*github.RepositoryComment))
+	})
+	return _c
+}
+
+func (_c *MockCommitService_CreateComment_Call) Return(_a0 *github.RepositoryComment, _a1 *github.Response, _a2 error) *MockCommitService_CreateComment_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockCommitService_CreateComment_Call) RunAndReturn(run func(context.Context, string, string, string, *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error)) *MockCommitService_CreateComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteComment provides a mock function with given fields: ctx, owner, repo, id
+func (_m *MockCommitService) DeleteComment(ctx context.Context, owner string, repo string, id int64) (*github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 *github.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) (*github.Response, error)); ok {
+		return rf(ctx, owner, repo, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) *github.Response); ok {
+		r0 = rf(ctx, owner, repo, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, owner, repo, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCommitService_DeleteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteComment'
+type MockCommitService_DeleteComment_Call struct {
+	*mock.Call
+}
+
+// DeleteComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - id int64
+func (_e *MockCommitService_Expecter) DeleteComment(ctx interface{}, owner interface{}, repo interface{}, id interface{}) *MockCommitService_DeleteComment_Call {
+	return &MockCommitService_DeleteComment_Call{Call: _e.mock.On("DeleteComment", ctx, owner, repo, id)}
+}
+
+func (_c *MockCommitService_DeleteComment_Call) Run(run func(ctx context.Context, owner string, repo string, id int64)) *MockCommitService_DeleteComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockCommitService_DeleteComment_Call) Return(_a0 *github.Response, _a1 error) *MockCommitService_DeleteComment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCommitService_DeleteComment_Call) RunAndReturn(run func(context.Context, string, string, int64) (*github.Response, error)) *MockCommitService_DeleteComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCommitComments provides a mock function with given fields: ctx, owner, repo, sha, opts
+func (_m *MockCommitService) ListCommitComments(ctx context.Context, owner string, repo string, sha string, opts *github.ListOptions) ([]*github.RepositoryComment, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, sha, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCommitComments")
+	}
+
+	var r0 []*github.RepositoryComment
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *github.ListOptions) ([]*github.RepositoryComment, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, sha, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *github.ListOptions) []*github.RepositoryComment); ok {
+		r0 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.RepositoryComment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *github.ListOptions) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, *github.ListOptions) error); ok {
+		r2 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockCommitService_ListCommitComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCommitComments'
+type MockCommitService_ListCommitComments_Call struct {
+	*mock.Call
+}
+
+// ListCommitComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - sha string
+//   - opts *github.ListOptions
+func (_e *MockCommitService_Expecter) ListCommitComments(ctx interface{}, owner interface{}, repo interface{}, sha interface{}, opts interface{}) *MockCommitService_ListCommitComments_Call {
+	return &MockCommitService_ListCommitComments_Call{Call: _e.mock.On("ListCommitComments", ctx, owner, repo, sha, opts)}
+}
+
+func (_c *MockCommitService_ListCommitComments_Call) Run(run func(ctx context.Context, owner string, repo string, sha string, opts *github.ListOptions)) *MockCommitService_ListCommitComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(*github.ListOptions))
+	})
+	return _c
+}
+
+func (_c *MockCommitService_ListCommitComments_Call) Return(_a0 []*github.RepositoryComment, _a1 *github.Response, _a2 error) *MockCommitService_ListCommitComments_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockCommitService_ListCommitComments_Call) RunAndReturn(run func(context.Context, string, string, string, *github.ListOptions) ([]*github.RepositoryComment, *github.Response, error)) *MockCommitService_ListCommitComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateComment provides a mock function with given fields: ctx, owner, repo, id, comment
+func (_m *MockCommitService) UpdateComment(ctx context.Context, owner string, repo string, id int64, comment *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, id, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateComment")
+	}
+
+	var r0 *github.RepositoryComment
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, id, comment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, *github.RepositoryComment) *github.RepositoryComment); ok {
+		r0 = rf(ctx, owner, repo, id, comment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.RepositoryComment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, *github.RepositoryComment) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, id, comment)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int64, *github.RepositoryComment) error); ok {
+		r2 = rf(ctx, owner, repo, id, comment)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockCommitService_UpdateComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateComment'
+type MockCommitService_UpdateComment_Call struct {
+	*mock.Call
+}
+
+// UpdateComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - id int64
+//   - comment *github.RepositoryComment
+func (_e *MockCommitService_Expecter) UpdateComment(ctx interface{}, owner interface{}, repo interface{}, id interface{}, comment interface{}) *MockCommitService_UpdateComment_Call {
+	return &MockCommitService_UpdateComment_Call{Call: _e.mock.On("UpdateComment", ctx, owner, repo, id, comment)}
+}
+
+func (_c *MockCommitService_UpdateComment_Call) Run(run func(ctx context.Context, owner string, repo string, id int64, comment *github.RepositoryComment)) *MockCommitService_UpdateComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64), args[4].(*github.RepositoryComment))
+	})
+	return _c
+}
+
+func (_c *MockCommitService_UpdateComment_Call) Return(_a0 *github.RepositoryComment, _a1 *github.Response, _a2 error) *MockCommitService_UpdateComment_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockCommitService_UpdateComment_Call) RunAndReturn(run func(context.Context, string, string, int64, *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error)) *MockCommitService_UpdateComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCommitService creates a new instance of MockCommitService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCommitService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCommitService {
+	mock := &MockCommitService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
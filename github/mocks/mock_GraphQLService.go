@@ -0,0 +1,143 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGraphQLService is an autogenerated mock type for the GraphQLService type
+type MockGraphQLService struct {
+	mock.Mock
+}
+
+type MockGraphQLService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGraphQLService) EXPECT() *MockGraphQLService_Expecter {
+	return &MockGraphQLService_Expecter{mock: &_m.Mock}
+}
+
+// MinimizeComment provides a mock function with given fields: ctx, id, classifier
+func (_m *MockGraphQLService) MinimizeComment(ctx context.Context, id string, classifier string) error {
+	ret := _m.Called(ctx, id, classifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MinimizeComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, classifier)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockGraphQLService_MinimizeComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MinimizeComment'
+type MockGraphQLService_MinimizeComment_Call struct {
+	*mock.Call
+}
+
+// MinimizeComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - classifier string
+func (_e *MockGraphQLService_Expecter) MinimizeComment(ctx interface{}, id interface{}, classifier interface{}) *MockGraphQLService_MinimizeComment_Call {
+	return &MockGraphQLService_MinimizeComment_Call{Call: _e.mock.On("MinimizeComment", ctx, id, classifier)}
+}
+
+func (_c *MockGraphQLService_MinimizeComment_Call) Run(run func(ctx context.Context, id string, classifier string)) *MockGraphQLService_MinimizeComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockGraphQLService_MinimizeComment_Call) Return(_a0 error) *MockGraphQLService_MinimizeComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGraphQLService_MinimizeComment_Call) RunAndReturn(run func(context.Context, string, string) error) *MockGraphQLService_MinimizeComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MinimizedComments provides a mock function with given fields: ctx, ids
+func (_m *MockGraphQLService) MinimizedComments(ctx context.Context, ids []string) (map[string]bool, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MinimizedComments")
+	}
+
+	var r0 map[string]bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]bool, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]bool); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGraphQLService_MinimizedComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MinimizedComments'
+type MockGraphQLService_MinimizedComments_Call struct {
+	*mock.Call
+}
+
+// MinimizedComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *MockGraphQLService_Expecter) MinimizedComments(ctx interface{}, ids interface{}) *MockGraphQLService_MinimizedComments_Call {
+	return &MockGraphQLService_MinimizedComments_Call{Call: _e.mock.On("MinimizedComments", ctx, ids)}
+}
+
+func (_c *MockGraphQLService_MinimizedComments_Call) Run(run func(ctx context.Context, ids []string)) *MockGraphQLService_MinimizedComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockGraphQLService_MinimizedComments_Call) Return(_a0 map[string]bool, _a1 error) *MockGraphQLService_MinimizedComments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGraphQLService_MinimizedComments_Call) RunAndReturn(run func(context.Context, []string) (map[string]bool, error)) *MockGraphQLService_MinimizedComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGraphQLService creates a new instance of MockGraphQLService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGraphQLService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGraphQLService {
+	mock := &MockGraphQLService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
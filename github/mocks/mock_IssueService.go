@@ -1,4 +1,4 @@
-// Code generated by mockery v2.43.0. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package mocks
 
@@ -93,6 +93,67 @@ func (_c *MockIssueService_CreateComment_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// DeleteComment provides a mock function with given fields: ctx, owner, repo, commentID
+func (_m *MockIssueService) DeleteComment(ctx context.Context, owner string, repo string, commentID int64) (*github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 *github.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) (*github.Response, error)); ok {
+		return rf(ctx, owner, repo, commentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) *github.Response); ok {
+		r0 = rf(ctx, owner, repo, commentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, owner, repo, commentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIssueService_DeleteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteComment'
+type MockIssueService_DeleteComment_Call struct {
+	*mock.Call
+}
+
+// DeleteComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - commentID int64
+func (_e *MockIssueService_Expecter) DeleteComment(ctx interface{}, owner interface{}, repo interface{}, commentID interface{}) *MockIssueService_DeleteComment_Call {
+	return &MockIssueService_DeleteComment_Call{Call: _e.mock.On("DeleteComment", ctx, owner, repo, commentID)}
+}
+
+func (_c *MockIssueService_DeleteComment_Call) Run(run func(ctx context.Context, owner string, repo string, commentID int64)) *MockIssueService_DeleteComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockIssueService_DeleteComment_Call) Return(_a0 *github.Response, _a1 error) *MockIssueService_DeleteComment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIssueService_DeleteComment_Call) RunAndReturn(run func(context.Context, string, string, int64) (*github.Response, error)) *MockIssueService_DeleteComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EditComment provides a mock function with given fields: ctx, owner, repo, commentID, comment
 func (_m *MockIssueService) EditComment(ctx context.Context, owner string, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
 	ret := _m.Called(ctx, owner, repo, commentID, comment)
@@ -235,6 +296,77 @@ func (_c *MockIssueService_ListComments_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// ListPullRequestsWithCommit provides a mock function with given fields: ctx, owner, repo, sha, opts
+func (_m *MockIssueService) ListPullRequestsWithCommit(ctx context.Context, owner string, repo string, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, sha, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPullRequestsWithCommit")
+	}
+
+	var r0 []*github.PullRequest
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *github.ListOptions) ([]*github.PullRequest, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, sha, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *github.ListOptions) []*github.PullRequest); ok {
+		r0 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *github.ListOptions) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, *github.ListOptions) error); ok {
+		r2 = rf(ctx, owner, repo, sha, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockIssueService_ListPullRequestsWithCommit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPullRequestsWithCommit'
+type MockIssueService_ListPullRequestsWithCommit_Call struct {
+	*mock.Call
+}
+
+// ListPullRequestsWithCommit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - repo string
+//   - sha string
+//   - opts *github.ListOptions
+func (_e *MockIssueService_Expecter) ListPullRequestsWithCommit(ctx interface{}, owner interface{}, repo interface{}, sha interface{}, opts interface{}) *MockIssueService_ListPullRequestsWithCommit_Call {
+	return &MockIssueService_ListPullRequestsWithCommit_Call{Call: _e.mock.On("ListPullRequestsWithCommit", ctx, owner, repo, sha, opts)}
+}
+
+func (_c *MockIssueService_ListPullRequestsWithCommit_Call) Run(run func(ctx context.Context, owner string, repo string, sha string, opts *github.ListOptions)) *MockIssueService_ListPullRequestsWithCommit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(*github.ListOptions))
+	})
+	return _c
+}
+
+func (_c *MockIssueService_ListPullRequestsWithCommit_Call) Return(_a0 []*github.PullRequest, _a1 *github.Response, _a2 error) *MockIssueService_ListPullRequestsWithCommit_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockIssueService_ListPullRequestsWithCommit_Call) RunAndReturn(run func(context.Context, string, string, string, *github.ListOptions) ([]*github.PullRequest, *github.Response, error)) *MockIssueService_ListPullRequestsWithCommit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockIssueService creates a new instance of MockIssueService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockIssueService(t interface {
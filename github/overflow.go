@@ -0,0 +1,260 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v67/github"
+)
+
+const (
+	// MaxCommentLength is the maximum length of a comment body accepted by the GitHub API.
+	// It is also applied to Gitea and Forgejo, which do not enforce a limit.
+	MaxCommentLength = 65536
+	// MaxGitLabCommentLength is the maximum length of a note body accepted by the GitLab API.
+	MaxGitLabCommentLength = 1000000
+
+	OverflowError    = "error"
+	OverflowTruncate = "truncate"
+	OverflowSplit    = "split"
+	OverflowGist     = "gist"
+
+	// overflowReserve is kept free in every part for the part suffix of the key,
+	// the part header and closing and reopening code fences.
+	overflowReserve = 128
+
+	codeFence = "```"
+)
+
+var ErrCommentTooLong = errors.New("comment exceeds the maximum length")
+
+// fitContent applies the overflow policy to the comment content and returns the comment parts.
+// The first part is the keyed comment itself, all other parts are continuation comments.
+func fitContent(content string, limit int, policy, url string) ([]string, error) {
+	if len(content) <= limit {
+		return []string{content}, nil
+	}
+
+	switch policy {
+	case OverflowTruncate:
+		return []string{truncateContent(content, limit, url)}, nil
+	case OverflowSplit:
+		return splitContent(content, limit), nil
+	}
+
+	return nil, fmt.Errorf("%w: %d of %d characters", ErrCommentTooLong, len(content), limit)
+}
+
+// MaxForgeCommentLength returns the maximum length of a comment body accepted by the API of
+// the given forge.
+func MaxForgeCommentLength(forge string) int {
+	if forge == ForgeGitLab {
+		return MaxGitLabCommentLength
+	}
+
+	return MaxCommentLength
+}
+
+// contentLimit returns the maximum length of the content of a comment with the given key and
+// metadata if the comment body can hold up to maxLength characters.
+func contentLimit(maxLength int, key string, meta Metadata) int {
+	return maxLength - len(withMetadata("", key, meta)) - overflowReserve
+}
+
+// maxCommentLength returns the maximum length of a comment body accepted by the forge of the
+// issue client.
+func (i *Issue) maxCommentLength() int {
+	if i.maxLength > 0 {
+		return i.maxLength
+	}
+
+	return MaxCommentLength
+}
+
+// truncateContent cuts the content to the given size and adds a footer that links to the
+// given URL, e.g. the pipeline that produced the full output.
+func truncateContent(content string, size int, url string) string {
+	footer := "\n\n---\n:warning: This comment was truncated because it exceeds the maximum length."
+	if url != "" {
+		footer += fmt.Sprintf(" See the [pipeline](%s) for the full output.", url)
+	}
+
+	head, _ := cutContent(content, size-len(footer))
+
+	return head + footer
+}
+
+// splitContent splits the content into parts of the given size. Continuation parts get a
+// header with the part number.
+func splitContent(content string, size int) []string {
+	var parts []string
+
+	for len(content) > size {
+		head, tail := cutContent(content, size)
+		parts = append(parts, head)
+		content = tail
+	}
+
+	parts = append(parts, content)
+
+	for n := 1; n < len(parts); n++ {
+		parts[n] = fmt.Sprintf("_Part %d of %d_\n\n%s", n+1, len(parts), parts[n])
+	}
+
+	return parts
+}
+
+// cutContent cuts the content at the last line break within the given size, or at the last
+// rune boundary if there is none. Code fences that are open at the cut are closed in the head
+// and reopened in the tail to keep the formatting of both parts intact.
+func cutContent(content string, size int) (string, string) {
+	size = max(size-len(codeFence)-1, 0)
+
+	cut := strings.LastIndexByte(content[:size], '\n')
+	if cut <= 0 {
+		cut = size
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+	}
+
+	head, tail := content[:cut], strings.TrimPrefix(content[cut:], "\n")
+
+	if openFence(head) {
+		head += "\n" + codeFence
+		tail = codeFence + "\n" + tail
+	}
+
+	return head, tail
+}
+
+// openFence reports whether the content ends within a code block.
+func openFence(content string) bool {
+	open := false
+
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), codeFence) {
+			open = !open
+		}
+	}
+
+	return open
+}
+
+// partKey returns the key of the continuation comment with the given part number.
+func partKey(key string, part int) string {
+	return fmt.Sprintf("%s/part-%d", key, part)
+}
+
+// parsePartKey returns the part number of the given continuation comment key.
+func parsePartKey(partKey, key string) (int, bool) {
+	suffix, ok := strings.CutPrefix(partKey, key+"/part-")
+	if !ok {
+		return 0, false
+	}
+
+	part, err := strconv.Atoi(suffix)
+	if err != nil || part < 2 {
+		return 0, false
+	}
+
+	return part, true
+}
+
+// writeParts writes the continuation comments for the given parts, removes the ones no longer
+// needed and reports whether any continuation comment was changed.
+func (i *Issue) writeParts(ctx context.Context, parts []string) (bool, error) {
+	existing := make(map[int]*github.IssueComment)
+
+	if i.Opt.Update && !i.Opt.HidePrevious {
+		var err error
+		if existing, err = i.findParts(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	changed := false
+
+	for idx, content := range parts {
+		part := idx + 2
+		comment := &github.IssueComment{
+			Body: github.String(withMetadata(content, partKey(i.Opt.Key, part), i.Opt.Metadata)),
+		}
+
+		current, ok := existing[part]
+		delete(existing, part)
+
+		switch {
+		case !ok:
+			if _, _, err := i.client.CreateComment(ctx, i.Opt.Owner, i.Opt.Repo, i.Opt.Number, comment); err != nil {
+				return false, fmt.Errorf("failed to create part %d: %w", part, err)
+			}
+		case !sameContent(current.GetBody(), content):
+			if _, _, err := i.client.EditComment(ctx, i.Opt.Owner, i.Opt.Repo, current.GetID(), comment); err != nil {
+				return false, fmt.Errorf("failed to update part %d: %w", part, err)
+			}
+		default:
+			continue
+		}
+
+		changed = true
+	}
+
+	for part, comment := range existing {
+		if _, err := i.client.DeleteComment(ctx, i.Opt.Owner, i.Opt.Repo, comment.GetID()); err != nil {
+			return false, fmt.Errorf("failed to delete part %d: %w", part, err)
+		}
+
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// deleteParts deletes all continuation comments of the comment with the specified key.
+func (i *Issue) deleteParts(ctx context.Context) error {
+	existing, err := i.findParts(ctx)
+	if err != nil {
+		return err
+	}
+
+	for part, comment := range existing {
+		if _, err := i.client.DeleteComment(ctx, i.Opt.Owner, i.Opt.Repo, comment.GetID()); err != nil {
+			return fmt.Errorf("failed to delete part %d: %w", part, err)
+		}
+	}
+
+	return nil
+}
+
+// findParts returns the newest continuation comment of the comment with the specified key
+// for each part number.
+func (i *Issue) findParts(ctx context.Context) (map[int]*github.IssueComment, error) {
+	parts := make(map[int]*github.IssueComment)
+
+	isPart := func(key string) bool {
+		_, ok := parsePartKey(key, i.Opt.Key)
+
+		return ok
+	}
+
+	err := i.scanKeyed(ctx, isPart, func(comment *github.IssueComment) bool {
+		meta, _ := parseMetadata(comment.GetBody())
+		part, _ := parsePartKey(meta.Key, i.Opt.Key)
+
+		if _, ok := parts[part]; !ok {
+			parts[part] = comment
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parts, nil
+}
@@ -0,0 +1,182 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thegeeklab/wp-github-comment/github/mocks"
+)
+
+func TestFitContent(t *testing.T) {
+	long := strings.Repeat("test line\n", MaxCommentLength/8)
+	limit := contentLimit(MaxCommentLength, "key", Metadata{})
+
+	t.Run("fits", func(t *testing.T) {
+		got, err := fitContent("test message", limit, OverflowError, "")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"test message"}, got)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := fitContent(long, limit, OverflowError, "")
+		assert.ErrorIs(t, err, ErrCommentTooLong)
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		got, err := fitContent(long, limit, OverflowTruncate, "https://ci.example.com/1")
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.LessOrEqual(t, len(withMetadata(got[0], "key", Metadata{})), MaxCommentLength)
+		assert.True(t, strings.HasPrefix(got[0], "test line\ntest line\n"))
+		assert.True(t, strings.HasSuffix(got[0], "See the [pipeline](https://ci.example.com/1) for the full output."))
+	})
+
+	t.Run("split", func(t *testing.T) {
+		got, err := fitContent(long, limit, OverflowSplit, "")
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+
+		for n, part := range got {
+			assert.LessOrEqual(t, len(withMetadata(part, partKey("key", n+1), Metadata{})), MaxCommentLength)
+		}
+
+		assert.True(t, strings.HasPrefix(got[1], "_Part 2 of 2_\n\ntest line\n"))
+		assert.Equal(t, long, got[0]+"\n"+strings.TrimPrefix(got[1], "_Part 2 of 2_\n\n"))
+	})
+}
+
+func TestCutContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		size     int
+		wantHead string
+		wantTail string
+	}{
+		{
+			name:     "cut at line break",
+			content:  "first line\nsecond line",
+			size:     18,
+			wantHead: "first line",
+			wantTail: "second line",
+		},
+		{
+			name:     "cut at rune boundary",
+			content:  "äöüäöü",
+			size:     9,
+			wantHead: "äö",
+			wantTail: "üäöü",
+		},
+		{
+			name:     "reopen code fence",
+			content:  "```\nfirst line\nsecond line\n```",
+			size:     22,
+			wantHead: "```\nfirst line\n```",
+			wantTail: "```\nsecond line\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, tail := cutContent(tt.content, tt.size)
+			assert.Equal(t, tt.wantHead, head)
+			assert.Equal(t, tt.wantTail, tail)
+		})
+	}
+}
+
+func TestParsePartKey(t *testing.T) {
+	part, ok := parsePartKey(partKey("key", 3), "key")
+	assert.True(t, ok)
+	assert.Equal(t, 3, part)
+
+	_, ok = parsePartKey("key", "key")
+	assert.False(t, ok)
+
+	_, ok = parsePartKey(partKey("other", 2), "key")
+	assert.False(t, ok)
+}
+
+func TestGithubIssue_AddComment_Split(t *testing.T) {
+	message := strings.Repeat("test line\n", MaxCommentLength/8)
+
+	parts, err := fitContent(message, contentLimit(MaxCommentLength, "test-key", Metadata{}), OverflowSplit, "")
+	assert.NoError(t, err)
+	assert.Len(t, parts, 2)
+
+	mockClient := mocks.NewMockIssueService(t)
+	issue := &Issue{
+		client: mockClient,
+		Opt: IssueOptions{
+			Key:      "test-key",
+			Owner:    "test-owner",
+			Repo:     "test-repo",
+			Message:  message,
+			Update:   true,
+			Overflow: OverflowSplit,
+		},
+	}
+
+	mockClient.
+		On("ListComments", mock.Anything, "test-owner", "test-repo", mock.Anything, mock.Anything).
+		Return([]*github.IssueComment{
+			{ID: github.Int64(1), Body: github.String(withMetadata("old", "test-key", Metadata{}))},
+			{ID: github.Int64(2), Body: github.String(withMetadata("old part", partKey("test-key", 2), Metadata{}))},
+			{ID: github.Int64(3), Body: github.String(withMetadata("old part", partKey("test-key", 3), Metadata{}))},
+		}, nil, nil)
+
+	primary := &github.IssueComment{Body: github.String(withMetadata(parts[0], "test-key", Metadata{}))}
+	mockClient.
+		On("EditComment", mock.Anything, "test-owner", "test-repo", int64(1), primary).
+		Return(primary, nil, nil).
+		Once()
+
+	part := &github.IssueComment{Body: github.String(withMetadata(parts[1], partKey("test-key", 2), Metadata{}))}
+	mockClient.
+		On("EditComment", mock.Anything, "test-owner", "test-repo", int64(2), part).
+		Return(part, nil, nil).
+		Once()
+
+	mockClient.
+		On("DeleteComment", mock.Anything, "test-owner", "test-repo", int64(3)).
+		Return(nil, nil).
+		Once()
+
+	got, action, err := issue.AddComment(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, primary, got)
+	assert.Equal(t, ActionUpdated, action)
+}
+
+func TestGithubIssue_AddComment_MaxLength(t *testing.T) {
+	message := strings.Repeat("test line\n", MaxCommentLength/8)
+	body := withMetadata(message, "test-key", Metadata{})
+
+	mockClient := mocks.NewMockIssueService(t)
+	issue := &Issue{
+		client:    mockClient,
+		maxLength: MaxGitLabCommentLength,
+		Opt: IssueOptions{
+			Key:      "test-key",
+			Owner:    "test-owner",
+			Repo:     "test-repo",
+			Number:   1,
+			Message:  message,
+			Overflow: OverflowError,
+		},
+	}
+
+	// The message exceeds the limit of GitHub, but fits into a single GitLab note.
+	mockClient.
+		On("CreateComment", mock.Anything, "test-owner", "test-repo", 1, &github.IssueComment{Body: &body}).
+		Return(&github.IssueComment{ID: github.Int64(1)}, nil, nil).
+		Once()
+
+	_, action, err := issue.AddComment(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ActionCreated, action)
+}
@@ -0,0 +1,46 @@
+package github
+
+import (
+	"github.com/google/go-github/v67/github"
+)
+
+// commentsPerPage is the maximum page size supported by the GitHub API.
+const commentsPerPage = 100
+
+// scanNewestFirst walks through a paginated list of comments from the newest to the oldest
+// and calls visit for each of them until visit returns false.
+func scanNewestFirst[T any](list func(page int) ([]T, *github.Response, error), visit func(T) bool) error {
+	first, resp, err := list(1)
+	if err != nil {
+		return err
+	}
+
+	if resp != nil {
+		for page := resp.LastPage; page > 1; page-- {
+			items, _, err := list(page)
+			if err != nil {
+				return err
+			}
+
+			if !visitReverse(items, visit) {
+				return nil
+			}
+		}
+	}
+
+	visitReverse(first, visit)
+
+	return nil
+}
+
+// visitReverse calls visit for each item in reverse order until visit returns false.
+// It reports whether all items were visited.
+func visitReverse[T any](items []T, visit func(T) bool) bool {
+	for i := len(items) - 1; i >= 0; i-- {
+		if !visit(items[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	retryMinBackoff = 1 * time.Second
+	retryMaxBackoff = 30 * time.Second
+)
+
+// RetryOptions holds the settings for retrying failed GitHub API requests.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of attempts per request, including the first one.
+	MaxAttempts int
+	// Budget is the time after the creation of the transport in which retries may start. It does
+	// not limit a single request. Zero means no limit.
+	Budget time.Duration
+}
+
+// RetryTransport is a http.RoundTripper that retries rate limited requests and, for idempotent
+// or read-only requests, transient server and network errors.
+type RetryTransport struct {
+	base  http.RoundTripper
+	opt   RetryOptions
+	start time.Time
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// NewRetryTransport creates a new RetryTransport that wraps the given transport.
+// If base is nil, http.DefaultTransport is used.
+func NewRetryTransport(base http.RoundTripper, opt RetryOptions) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &RetryTransport{
+		base:       base,
+		opt:        opt,
+		start:      time.Now(),
+		minBackoff: retryMinBackoff,
+		maxBackoff: retryMaxBackoff,
+	}
+}
+
+// RoundTrip executes the request and retries it until it succeeds, the error is not
+// retryable, the maximum number of attempts is reached or the retry budget is exhausted.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		r, err := rewind(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(r)
+
+		wait, retry := t.backoff(req, resp, err, attempt)
+		if !retry || attempt >= t.opt.MaxAttempts {
+			return resp, err
+		}
+
+		if t.opt.Budget > 0 && time.Since(t.start)+wait > t.opt.Budget {
+			return resp, err
+		}
+
+		logger := log.Warn().
+			Str("method", req.Method).
+			Str("url", req.URL.Redacted()).
+			Int("attempt", attempt).
+			Dur("wait", wait)
+
+		if err != nil {
+			logger = logger.Err(err)
+		}
+
+		if resp != nil {
+			logger = logger.Int("status", resp.StatusCode)
+
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		logger.Msg("github request failed, retrying")
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// backoff returns the time to wait before the next attempt and whether the request
+// should be retried at all.
+func (t *RetryTransport) backoff(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		// Transient network errors are retried, a cancelled request is not.
+		return t.exponential(attempt), idempotent(req) && req.Context().Err() == nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return t.exponential(attempt), idempotent(req)
+	case http.StatusForbidden, http.StatusTooManyRequests:
+	default:
+		return 0, false
+	}
+
+	var (
+		abuseErr *github.AbuseRateLimitError
+		rateErr  *github.RateLimitError
+	)
+
+	switch checkErr := github.CheckResponse(resp); {
+	case errors.As(checkErr, &abuseErr):
+		if abuseErr.RetryAfter != nil {
+			return *abuseErr.RetryAfter, true
+		}
+
+		return t.exponential(attempt), true
+	case errors.As(checkErr, &rateErr):
+		return time.Until(rateErr.Rate.Reset.Time) + time.Second, true
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+
+		return t.exponential(attempt), true
+	}
+
+	return 0, false
+}
+
+type readOnlyKey struct{}
+
+// withReadOnly marks the requests of the context as read-only, e.g. GraphQL queries that are
+// sent as POST requests, so they are retried like idempotent requests.
+func withReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// idempotent reports whether the request can be repeated safely.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+
+	readOnly, _ := req.Context().Value(readOnlyKey{}).(bool)
+
+	return readOnly
+}
+
+// exponential returns an exponential backoff with jitter for the given attempt.
+func (t *RetryTransport) exponential(attempt int) time.Duration {
+	wait := t.maxBackoff
+	if shift := attempt - 1; shift < 16 {
+		wait = min(t.minBackoff<<shift, t.maxBackoff)
+	}
+
+	if wait <= 0 {
+		return 0
+	}
+
+	//nolint:gosec
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// rewind returns the request for the given attempt. Subsequent attempts need a fresh
+// copy of the request body.
+func rewind(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.Body == nil || req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	r := req.Clone(req.Context())
+	r.Body = body
+
+	return r, nil
+}
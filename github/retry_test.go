@@ -0,0 +1,185 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryTransport_RoundTrip(t *testing.T) {
+	secondaryRateLimit := func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{
+			"message": "You have exceeded a secondary rate limit.",
+			"documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"
+		}`))
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		readOnly     bool
+		responses    []func(w http.ResponseWriter)
+		opt          RetryOptions
+		wantStatus   int
+		wantAttempts int
+	}{
+		{
+			name:   "retry server error",
+			method: http.MethodGet,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			opt:          RetryOptions{MaxAttempts: 3},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name: "retry secondary rate limit",
+			responses: []func(w http.ResponseWriter){
+				secondaryRateLimit,
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusCreated) },
+			},
+			opt:          RetryOptions{MaxAttempts: 3},
+			wantStatus:   http.StatusCreated,
+			wantAttempts: 2,
+		},
+		{
+			name: "retry too many requests",
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+				},
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			opt:          RetryOptions{MaxAttempts: 3},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name: "no retry of post on server error",
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusCreated) },
+			},
+			opt:          RetryOptions{MaxAttempts: 3},
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: 1,
+		},
+		{
+			name:     "retry read-only post on server error",
+			readOnly: true,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			opt:          RetryOptions{MaxAttempts: 3},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:   "max attempts exceeded",
+			method: http.MethodDelete,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) },
+			},
+			opt:          RetryOptions{MaxAttempts: 3},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 3,
+		},
+		{
+			name:   "budget exhausted",
+			method: http.MethodGet,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) },
+			},
+			opt:          RetryOptions{MaxAttempts: 3, Budget: time.Nanosecond},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 1,
+		},
+		{
+			name: "no retry on client error",
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusNotFound) },
+			},
+			opt:          RetryOptions{MaxAttempts: 3},
+			wantStatus:   http.StatusNotFound,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body := make([]byte, r.ContentLength)
+				_, _ = r.Body.Read(body)
+				assert.Equal(t, "test body", string(body))
+
+				tt.responses[min(attempts, len(tt.responses)-1)](w)
+				attempts++
+			}))
+			defer server.Close()
+
+			transport := NewRetryTransport(server.Client().Transport, tt.opt)
+			transport.minBackoff = time.Millisecond
+			transport.maxBackoff = time.Millisecond
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+
+			ctx := context.Background()
+			if tt.readOnly {
+				ctx = withReadOnly(ctx)
+			}
+
+			req, _ := http.NewRequestWithContext(ctx, method, server.URL, strings.NewReader("test body"))
+
+			resp, err := transport.RoundTrip(req)
+			assert.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantAttempts, attempts)
+		})
+	}
+}
+
+func TestRetryTransport_Budget(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	transport := NewRetryTransport(server.Client().Transport, RetryOptions{MaxAttempts: 3, Budget: time.Minute})
+	transport.minBackoff = time.Millisecond
+	transport.maxBackoff = time.Millisecond
+
+	// The budget applies to all requests of the transport, not to each request.
+	transport.start = time.Now().Add(-time.Minute)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+
+	resp, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 1, attempts)
+}
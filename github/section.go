@@ -0,0 +1,338 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	sectionMaxAttempts = 5
+	sectionSettleDelay = 2 * time.Second
+)
+
+var ErrSectionConflict = errors.New("section conflict")
+
+type section struct {
+	name    string
+	content string
+}
+
+func sectionStart(name string) string {
+	return fmt.Sprintf("<!-- section: %s -->", name)
+}
+
+func sectionEnd(name string) string {
+	return fmt.Sprintf("<!-- section-end: %s -->", name)
+}
+
+// parseSections returns the named sections of the given comment body in order of appearance.
+// Content outside of sections is ignored.
+func parseSections(body string) []section {
+	var sections []section
+
+	const prefix, suffix = "<!-- section: ", " -->"
+
+	rest := body
+
+	for {
+		start := strings.Index(rest, prefix)
+		if start < 0 {
+			break
+		}
+
+		rest = rest[start+len(prefix):]
+
+		nameEnd := strings.Index(rest, suffix)
+		if nameEnd < 0 {
+			break
+		}
+
+		name := rest[:nameEnd]
+		rest = strings.TrimPrefix(rest[nameEnd+len(suffix):], "\n")
+
+		end := "\n" + sectionEnd(name)
+
+		contentEnd := strings.Index(rest, end)
+		if contentEnd < 0 {
+			break
+		}
+
+		sections = append(sections, section{name: name, content: rest[:contentEnd]})
+		rest = rest[contentEnd+len(end):]
+	}
+
+	return sections
+}
+
+// findSection returns the content of the named section of the given comment body.
+func findSection(body, name string) (string, bool) {
+	for _, s := range parseSections(body) {
+		if s.name == name {
+			return s.content, true
+		}
+	}
+
+	return "", false
+}
+
+// renderSections returns the comment content for the given sections without the metadata block.
+// Sections are sorted by name to keep the layout stable regardless of the order of the writers.
+func renderSections(sections []section) string {
+	slices.SortFunc(sections, func(a, b section) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	parts := make([]string, 0, len(sections))
+	for _, s := range sections {
+		parts = append(parts, fmt.Sprintf("%s\n%s\n%s", sectionStart(s.name), s.content, sectionEnd(s.name)))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// mergeSections merges the sections of all given comment bodies. Sections of the first
+// body take precedence over sections with the same name in subsequent bodies. If content
+// is nil, the named section is removed, otherwise it is set to the given content.
+func mergeSections(bodies []string, name string, content *string) []section {
+	var sections []section
+
+	seen := make(map[string]bool)
+
+	for _, body := range bodies {
+		for _, s := range parseSections(body) {
+			if seen[s.name] || s.name == name {
+				continue
+			}
+
+			seen[s.name] = true
+			sections = append(sections, s)
+		}
+	}
+
+	if content != nil {
+		sections = append(sections, section{name: name, content: *content})
+	}
+
+	return sections
+}
+
+// AddSection adds or updates the named section of the GitHub issue comment that contains
+// the specified key while preserving all other sections written by concurrent steps.
+func (i *Issue) AddSection(ctx context.Context) (*github.IssueComment, Action, error) {
+	return i.updateSection(ctx, &i.Opt.Message)
+}
+
+// DeleteSection removes the named section of the GitHub issue comment that contains the
+// specified key. The comment is deleted if no section is left. It returns ErrCommentNotFound
+// if no such comment or section exists.
+func (i *Issue) DeleteSection(ctx context.Context) (*github.IssueComment, error) {
+	comment, action, err := i.updateSection(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if action == ActionUnchanged {
+		return nil, fmt.Errorf("%w: failed to find section %s", ErrCommentNotFound, i.Opt.Section)
+	}
+
+	return comment, nil
+}
+
+// updateSection writes the section until it is applied and returns the resulting comment and the
+// action of the last write.
+func (i *Issue) updateSection(ctx context.Context, content *string) (*github.IssueComment, Action, error) {
+	action := ActionUnchanged
+
+	for attempt := 1; attempt <= sectionMaxAttempts; attempt++ {
+		comments, err := i.FindComments(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(comments) == 0 && content == nil {
+			return nil, "", fmt.Errorf("%w: failed to find comment with key %s", ErrCommentNotFound, i.Opt.Key)
+		}
+
+		expected := content
+		if content != nil {
+			fitted, err := i.fitSection(comments, *content)
+			if err != nil {
+				return nil, "", err
+			}
+
+			expected = &fitted
+		}
+
+		written, err := i.writeSection(ctx, comments, expected)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Nothing was written, so the comments are still up to date.
+		if written == ActionUnchanged {
+			if comment, ok := i.sectionApplied(comments, expected); ok {
+				return comment, action, nil
+			}
+		} else {
+			action = written
+		}
+
+		if err := i.settle(ctx); err != nil {
+			return nil, "", err
+		}
+
+		if comments, err = i.FindComments(ctx); err != nil {
+			return nil, "", err
+		}
+
+		if comment, ok := i.sectionApplied(comments, expected); ok {
+			return comment, action, nil
+		}
+
+		log.Debug().
+			Str("section", i.Opt.Section).
+			Int("attempt", attempt).
+			Msg("section lost due to a conflicting edit, retrying")
+	}
+
+	return nil, "", fmt.Errorf(
+		"%w: failed to update section %s after %d attempts", ErrSectionConflict, i.Opt.Section, sectionMaxAttempts,
+	)
+}
+
+// fitSection applies the overflow policy to the section content, so the merged comment with
+// all other sections of the given comments does not exceed the maximum comment length.
+func (i *Issue) fitSection(comments []*github.IssueComment, content string) (string, error) {
+	bodies := make([]string, 0, len(comments))
+	for _, comment := range sortByID(comments) {
+		bodies = append(bodies, comment.GetBody())
+	}
+
+	empty := ""
+	overhead := len(renderSections(mergeSections(bodies, i.Opt.Section, &empty)))
+
+	limit := contentLimit(i.maxCommentLength(), i.Opt.Key, i.Opt.Metadata) - overhead
+
+	parts, err := fitContent(content, limit, i.Opt.Overflow, i.Opt.OverflowURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fit section %s: %w", i.Opt.Section, err)
+	}
+
+	if len(parts) > 1 {
+		return "", fmt.Errorf("%w: sections can not be split", ErrCommentTooLong)
+	}
+
+	return parts[0], nil
+}
+
+// writeSection merges the section into the oldest of the given comments and removes all
+// duplicates. If no comment is given, a new comment is created. It returns the action that
+// was performed on the oldest comment.
+func (i *Issue) writeSection(ctx context.Context, comments []*github.IssueComment, content *string) (Action, error) {
+	if len(comments) == 0 {
+		comment := &github.IssueComment{
+			Body: github.String(
+				withMetadata(renderSections(mergeSections(nil, i.Opt.Section, content)), i.Opt.Key, i.Opt.Metadata),
+			),
+		}
+		if _, _, err := i.client.CreateComment(ctx, i.Opt.Owner, i.Opt.Repo, i.Opt.Number, comment); err != nil {
+			return "", err
+		}
+
+		return ActionCreated, nil
+	}
+
+	comments = sortByID(comments)
+	primary := comments[0]
+
+	bodies := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		bodies = append(bodies, comment.GetBody())
+	}
+
+	sections := mergeSections(bodies, i.Opt.Section, content)
+	action := ActionUnchanged
+
+	switch rendered := renderSections(sections); {
+	case len(sections) == 0:
+		if _, err := i.client.DeleteComment(ctx, i.Opt.Owner, i.Opt.Repo, primary.GetID()); err != nil {
+			return "", err
+		}
+
+		action = ActionDeleted
+	case !sameContent(primary.GetBody(), rendered):
+		comment := &github.IssueComment{Body: github.String(withMetadata(rendered, i.Opt.Key, i.Opt.Metadata))}
+		if _, _, err := i.client.EditComment(ctx, i.Opt.Owner, i.Opt.Repo, primary.GetID(), comment); err != nil {
+			return "", err
+		}
+
+		action = ActionUpdated
+	}
+
+	for _, duplicate := range comments[1:] {
+		if _, err := i.client.DeleteComment(ctx, i.Opt.Owner, i.Opt.Repo, duplicate.GetID()); err != nil {
+			return "", err
+		}
+
+		if action == ActionUnchanged {
+			action = ActionUpdated
+		}
+	}
+
+	return action, nil
+}
+
+// sectionApplied reports whether the section is in the expected state, which requires a
+// single comment that holds the expected section content or no section at all for removals.
+func (i *Issue) sectionApplied(comments []*github.IssueComment, content *string) (*github.IssueComment, bool) {
+	if len(comments) == 0 {
+		return nil, content == nil
+	}
+
+	if len(comments) > 1 {
+		return nil, false
+	}
+
+	got, ok := findSection(comments[0].GetBody(), i.Opt.Section)
+	if content == nil {
+		return comments[0], !ok
+	}
+
+	return comments[0], ok && got == *content
+}
+
+// settle waits for a randomized delay to give concurrent writers the chance to finish their edits.
+func (i *Issue) settle(ctx context.Context) error {
+	if i.settleDelay <= 0 {
+		return nil
+	}
+
+	//nolint:gosec
+	delay := i.settleDelay + rand.N(i.settleDelay)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// sortByID returns the given comments sorted by ID in ascending order, so the oldest comment comes first.
+func sortByID(comments []*github.IssueComment) []*github.IssueComment {
+	sorted := slices.Clone(comments)
+	slices.SortFunc(sorted, func(a, b *github.IssueComment) int {
+		return cmp.Compare(a.GetID(), b.GetID())
+	})
+
+	return sorted
+}
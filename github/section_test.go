@@ -0,0 +1,229 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thegeeklab/wp-github-comment/github/mocks"
+)
+
+func TestMergeSections(t *testing.T) {
+	content := "new"
+
+	tests := []struct {
+		name    string
+		bodies  []string
+		content *string
+		want    string
+	}{
+		{
+			name:    "add section to empty comment",
+			content: &content,
+			want:    "<!-- section: test -->\nnew\n<!-- section-end: test -->",
+		},
+		{
+			name: "update section and keep others",
+			bodies: []string{
+				"<!-- section: a -->\nold a\n<!-- section-end: a -->\n\n" +
+					"<!-- section: test -->\nold\n<!-- section-end: test -->\n<!-- id: key -->\n",
+			},
+			content: &content,
+			want: "<!-- section: a -->\nold a\n<!-- section-end: a -->\n\n" +
+				"<!-- section: test -->\nnew\n<!-- section-end: test -->",
+		},
+		{
+			name: "merge duplicate comments",
+			bodies: []string{
+				"<!-- section: a -->\na\n<!-- section-end: a -->\n<!-- id: key -->\n",
+				"<!-- section: a -->\nstale a\n<!-- section-end: a -->\n\n" +
+					"<!-- section: z -->\nz\n<!-- section-end: z -->\n<!-- id: key -->\n",
+			},
+			content: &content,
+			want: "<!-- section: a -->\na\n<!-- section-end: a -->\n\n" +
+				"<!-- section: test -->\nnew\n<!-- section-end: test -->\n\n" +
+				"<!-- section: z -->\nz\n<!-- section-end: z -->",
+		},
+		{
+			name: "remove section",
+			bodies: []string{
+				"<!-- section: a -->\na\n<!-- section-end: a -->\n\n" +
+					"<!-- section: test -->\nold\n<!-- section-end: test -->\n<!-- id: key -->\n",
+			},
+			want: "<!-- section: a -->\na\n<!-- section-end: a -->",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderSections(mergeSections(tt.bodies, "test", tt.content))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGithubIssue_AddSection(t *testing.T) {
+	existing := "<!-- section: a -->\na\n<!-- section-end: a -->\n<!-- id: test-key -->\n"
+	conflicting := "<!-- section: b -->\nb\n<!-- section-end: b -->\n<!-- id: test-key -->\n"
+	merged := withMetadata("<!-- section: a -->\na\n<!-- section-end: a -->\n\n"+
+		"<!-- section: test -->\ntest message\n<!-- section-end: test -->", "test-key", Metadata{})
+	remerged := withMetadata("<!-- section: b -->\nb\n<!-- section-end: b -->\n\n"+
+		"<!-- section: test -->\ntest message\n<!-- section-end: test -->", "test-key", Metadata{})
+
+	mockClient := mocks.NewMockIssueService(t)
+	issue := &Issue{
+		client: mockClient,
+		Opt: IssueOptions{
+			Key:     "test-key",
+			Owner:   "test-owner",
+			Repo:    "test-repo",
+			Message: "test message",
+			Section: "test",
+		},
+	}
+
+	list := func(body string) {
+		mockClient.
+			On("ListComments", mock.Anything, "test-owner", "test-repo", mock.Anything, mock.Anything).
+			Return([]*github.IssueComment{{ID: github.Int64(1), Body: github.String(body)}}, nil, nil).
+			Once()
+	}
+
+	// The first edit is overwritten by a concurrent writer, the second attempt succeeds.
+	list(existing)
+	mockClient.
+		On("EditComment", mock.Anything, "test-owner", "test-repo", int64(1), &github.IssueComment{Body: &merged}).
+		Return(nil, nil, nil).
+		Once()
+	list(conflicting)
+	list(conflicting)
+	mockClient.
+		On("EditComment", mock.Anything, "test-owner", "test-repo", int64(1), &github.IssueComment{Body: &remerged}).
+		Return(nil, nil, nil).
+		Once()
+	list(remerged)
+
+	got, action, err := issue.AddComment(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, remerged, got.GetBody())
+	assert.Equal(t, ActionUpdated, action)
+}
+
+func TestGithubIssue_AddSection_Unchanged(t *testing.T) {
+	body := withMetadata("<!-- section: test -->\ntest message\n<!-- section-end: test -->", "test-key", Metadata{})
+
+	mockClient := mocks.NewMockIssueService(t)
+	issue := &Issue{
+		client: mockClient,
+		Opt: IssueOptions{
+			Key:     "test-key",
+			Owner:   "test-owner",
+			Repo:    "test-repo",
+			Message: "test message",
+			Section: "test",
+		},
+	}
+
+	mockClient.
+		On("ListComments", mock.Anything, "test-owner", "test-repo", mock.Anything, mock.Anything).
+		Return([]*github.IssueComment{{ID: github.Int64(1), Body: github.String(body)}}, nil, nil).
+		Once()
+
+	got, action, err := issue.AddComment(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, body, got.GetBody())
+	assert.Equal(t, ActionUnchanged, action)
+}
+
+func TestGithubIssue_AddSection_Overflow(t *testing.T) {
+	other := "<!-- section: a -->\n" + strings.Repeat("a", MaxCommentLength/2) + "\n<!-- section-end: a -->"
+	message := strings.Repeat("test line\n", MaxCommentLength/20)
+
+	tests := []struct {
+		name     string
+		overflow string
+		wantErr  error
+	}{
+		{name: "truncate", overflow: OverflowTruncate},
+		{name: "error", overflow: OverflowError, wantErr: ErrCommentTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := mocks.NewMockIssueService(t)
+			issue := &Issue{
+				client: mockClient,
+				Opt: IssueOptions{
+					Key:      "test-key",
+					Owner:    "test-owner",
+					Repo:     "test-repo",
+					Message:  message,
+					Section:  "test",
+					Overflow: tt.overflow,
+				},
+			}
+
+			var written string
+
+			mockClient.
+				On("ListComments", mock.Anything, "test-owner", "test-repo", mock.Anything, mock.Anything).
+				Return(func(context.Context, string, string, int, *github.IssueListCommentsOptions) []*github.IssueComment {
+					body := withMetadata(other, "test-key", Metadata{})
+					if written != "" {
+						body = written
+					}
+
+					return []*github.IssueComment{{ID: github.Int64(1), Body: github.String(body)}}
+				}, nil, nil)
+
+			if tt.wantErr == nil {
+				mockClient.
+					On("EditComment", mock.Anything, "test-owner", "test-repo", int64(1), mock.Anything).
+					Run(func(args mock.Arguments) {
+						written = args.Get(4).(*github.IssueComment).GetBody()
+					}).
+					Return(nil, nil, nil).
+					Once()
+			}
+
+			_, action, err := issue.AddComment(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, ActionUpdated, action)
+			assert.LessOrEqual(t, len(written), MaxCommentLength)
+			assert.Contains(t, written, other)
+			assert.Contains(t, written, "This comment was truncated")
+		})
+	}
+}
+
+func TestGithubIssue_DeleteSection_Missing(t *testing.T) {
+	body := withMetadata("<!-- section: a -->\na\n<!-- section-end: a -->", "test-key", Metadata{})
+
+	mockClient := mocks.NewMockIssueService(t)
+	issue := &Issue{
+		client: mockClient,
+		Opt: IssueOptions{
+			Key:     "test-key",
+			Owner:   "test-owner",
+			Repo:    "test-repo",
+			Section: "test",
+		},
+	}
+
+	mockClient.
+		On("ListComments", mock.Anything, "test-owner", "test-repo", mock.Anything, mock.Anything).
+		Return([]*github.IssueComment{{ID: github.Int64(1), Body: github.String(body)}}, nil, nil).
+		Once()
+
+	_, err := issue.DeleteComment(context.Background())
+	assert.ErrorIs(t, err, ErrCommentNotFound)
+}
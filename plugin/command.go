@@ -0,0 +1,177 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	plugin_base "github.com/thegeeklab/wp-plugin-go/v4/plugin"
+	"github.com/urfave/cli/v2"
+)
+
+const commandCategory = "Target Flags"
+
+// Target holds the comment target of a command, which replaces the repository and commit
+// metadata that is otherwise provided by the Woodpecker environment.
+type Target struct {
+	Owner  string
+	Repo   string
+	Number int
+	SHA    string
+}
+
+// Commands returns the subcommands that allow to use the plugin outside of Woodpecker.
+// The plugin settings are passed as global flags or environment variables, the commands
+// only add flags for the comment target.
+func (p *Plugin) Commands() []*cli.Command {
+	target := &Target{}
+
+	return []*cli.Command{
+		{
+			Name:   "post",
+			Usage:  "add or update the comment",
+			Flags:  TargetFlags(target, commandCategory),
+			Action: p.command(target, p.run),
+		},
+		{
+			Name:   "find",
+			Usage:  "print the comment as JSON without changing it",
+			Flags:  TargetFlags(target, commandCategory),
+			Action: p.command(target, p.find),
+		},
+		{
+			Name:  "delete",
+			Usage: "delete the comment",
+			Flags: TargetFlags(target, commandCategory),
+			Action: p.command(target, func(ctx context.Context) error {
+				p.Settings.Mode = ModeDelete
+
+				return p.run(ctx)
+			}),
+		},
+		{
+			Name:   "render",
+			Usage:  "print the rendered message without sending any requests",
+			Flags:  TargetFlags(target, commandCategory),
+			Action: p.command(target, p.render),
+		},
+	}
+}
+
+// TargetFlags returns a slice of CLI flags for the comment target of a command.
+func TargetFlags(target *Target, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "owner",
+			EnvVars:     []string{"GITHUB_COMMENT_OWNER", "GITHUB_REPOSITORY_OWNER"},
+			Usage:       "owner of the repository",
+			Destination: &target.Owner,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "repo",
+			EnvVars:     []string{"GITHUB_COMMENT_REPO", "GITHUB_REPOSITORY"},
+			Usage:       "name of the repository, either as name or in owner/name format",
+			Destination: &target.Repo,
+			Category:    category,
+		},
+		&cli.IntFlag{
+			Name:        "number",
+			EnvVars:     []string{"GITHUB_COMMENT_NUMBER"},
+			Usage:       "number of the issue or pull request to comment on",
+			Destination: &target.Number,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "sha",
+			EnvVars:     []string{"GITHUB_COMMENT_SHA", "GITHUB_SHA"},
+			Usage:       "commit SHA used for commit comments and to look up the associated pull requests",
+			Destination: &target.SHA,
+			Category:    category,
+		},
+	}
+}
+
+// command returns the action of a command. The action initializes the plugin from the CLI
+// context, applies the comment target and executes the given function.
+func (p *Plugin) command(target *Target, run plugin_base.ExecuteFunc) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		p.Metadata = plugin_base.MetadataFromContext(c)
+		p.Network = plugin_base.NetworkFromContext(c)
+		p.Context = c
+
+		target.apply(&p.Metadata, p.Settings)
+
+		return run(c.Context)
+	}
+}
+
+// apply overrides the repository and commit metadata with the target. Without an explicit
+// issue number and pipeline event, the pull requests associated with the commit are used
+// as comment target, the same way as for push events.
+func (t *Target) apply(meta *plugin_base.Metadata, settings *Settings) {
+	if owner, name, ok := strings.Cut(t.Repo, "/"); ok {
+		t.Owner, t.Repo = owner, name
+	}
+
+	if t.Owner != "" {
+		meta.Repository.Owner = t.Owner
+	}
+
+	if t.Repo != "" {
+		meta.Repository.Name = t.Repo
+	}
+
+	meta.Repository.Slug = fmt.Sprintf("%s/%s", meta.Repository.Owner, meta.Repository.Name)
+
+	if t.Number != 0 {
+		settings.IssueNum = t.Number
+	}
+
+	if t.SHA != "" {
+		meta.Curr.SHA = t.SHA
+
+		if meta.Pipeline.Event == "" {
+			meta.Pipeline.Event = EventPush
+		}
+	}
+}
+
+// find prints the results of the comment lookup to stdout.
+func (p *Plugin) find(_ context.Context) error {
+	p.Settings.Mode = modeFind
+
+	if err := p.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	results, err := p.execute()
+	if err != nil {
+		return fmt.Errorf("execution failed: %w", err)
+	}
+
+	data, err := marshalResults(results)
+	if err != nil {
+		return err
+	}
+
+	if _, err := p.stdout.Write(data); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+
+	return p.writeOutput(results)
+}
+
+// render prints the message to stdout the way it would be posted, without the hidden
+// comment metadata.
+func (p *Plugin) render(_ context.Context) error {
+	if err := p.loadMessage(); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(p.stdout, p.Settings.Message); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package plugin
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	plugin_base "github.com/thegeeklab/wp-plugin-go/v4/plugin"
+)
+
+// runCommand runs the plugin with the given arguments against a test server with the given
+// handler and returns the plugin and its output.
+func runCommand(t *testing.T, handler http.Handler, args ...string) (*Plugin, string, error) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	var out bytes.Buffer
+
+	p := New(nil)
+	p.stdout = &out
+
+	err := p.App.Run(append([]string{"wp-github-comment", "--api-key", "token", "--base-url", server.URL}, args...))
+
+	return p, out.String(), err
+}
+
+func TestTarget_Apply(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     Target
+		meta       plugin_base.Metadata
+		wantSlug   string
+		wantNumber int
+		wantSHA    string
+		wantEvent  string
+	}{
+		{
+			name:       "owner and repo",
+			target:     Target{Owner: "octocat", Repo: "hello-world", Number: 1},
+			wantSlug:   "octocat/hello-world",
+			wantNumber: 1,
+		},
+		{
+			name:      "repo slug",
+			target:    Target{Owner: "other", Repo: "octocat/hello-world", SHA: "abc123"},
+			wantSlug:  "octocat/hello-world",
+			wantSHA:   "abc123",
+			wantEvent: EventPush,
+		},
+		{
+			name:   "keep pipeline metadata",
+			target: Target{SHA: "abc123"},
+			meta: func() plugin_base.Metadata {
+				meta := plugin_base.Metadata{}
+				meta.Repository.Owner = "octocat"
+				meta.Repository.Name = "hello-world"
+				meta.Pipeline.Event = EventPullRequest
+
+				return meta
+			}(),
+			wantSlug:  "octocat/hello-world",
+			wantSHA:   "abc123",
+			wantEvent: EventPullRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &Settings{}
+
+			tt.target.apply(&tt.meta, settings)
+			assert.Equal(t, tt.wantSlug, tt.meta.Repository.Slug)
+			assert.Equal(t, tt.wantNumber, settings.IssueNum)
+			assert.Equal(t, tt.wantSHA, tt.meta.Curr.SHA)
+			assert.Equal(t, tt.wantEvent, tt.meta.Pipeline.Event)
+		})
+	}
+}
+
+func TestCommand_Find(t *testing.T) {
+	var written bool
+
+	key := "test-key"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, map[string]any{"login": "bot"})
+	})
+	mux.HandleFunc("GET "+testRepoPath+"/issues/1/comments", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, []map[string]any{{
+			"id":       1,
+			"body":     "<!-- id: " + key + " -->",
+			"html_url": "https://github.com/octocat/hello-world/pull/1#issuecomment-1",
+			"user":     map[string]any{"login": "bot"},
+		}})
+	})
+	mux.HandleFunc("/", func(_ http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			written = true
+		}
+	})
+
+	_, out, err := runCommand(t, mux, "--key", key, "find", "--repo", "octocat/hello-world", "--number", "1")
+	require.NoError(t, err)
+	assert.False(t, written)
+	assert.JSONEq(t, `[{
+		"action": "found",
+		"number": 1,
+		"comment_id": 1,
+		"html_url": "https://github.com/octocat/hello-world/pull/1#issuecomment-1"
+	}]`, out)
+}
+
+func TestCommand_Render(t *testing.T) {
+	var requested bool
+
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		requested = true
+	})
+
+	_, out, err := runCommand(t, handler,
+		"--message", "{{ .Repository.Slug }}@{{ .Curr.SHA }}", "--template",
+		"render", "--repo", "octocat/hello-world", "--sha", "abc123",
+	)
+	require.NoError(t, err)
+	assert.False(t, requested)
+	assert.Equal(t, "octocat/hello-world@abc123\n", out)
+}
+
+func TestCommand_Delete(t *testing.T) {
+	var deleted []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, map[string]any{"login": "bot"})
+	})
+	mux.HandleFunc("GET "+testRepoPath+"/issues/1/comments", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, []map[string]any{
+			{"id": 1, "body": "<!-- id: test-key -->", "user": map[string]any{"login": "bot"}},
+			{"id": 2, "body": "<!-- id: other-key -->", "user": map[string]any{"login": "bot"}},
+		})
+	})
+	mux.HandleFunc("DELETE "+testRepoPath+"/issues/comments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, r.PathValue("id"))
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Only the comment with the key is deleted, no message is required.
+	p, _, err := runCommand(t, mux, "--key", "test-key", "delete", "--repo", "octocat/hello-world", "--number", "1")
+	require.NoError(t, err)
+	assert.Equal(t, ModeDelete, p.Settings.Mode)
+	assert.Equal(t, []string{"1"}, deleted)
+}
@@ -5,15 +5,77 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	gh "github.com/thegeeklab/wp-github-comment/github"
 	plugin_file "github.com/thegeeklab/wp-plugin-go/v4/file"
+	"golang.org/x/oauth2"
 )
 
-var ErrPluginEventNotSupported = errors.New("event not supported")
+const (
+	DefaultBaseURL = "https://api.github.com/"
+
+	EventPullRequest = "pull_request"
+	EventPush        = "push"
+	EventTag         = "tag"
+	EventManual      = "manual"
+
+	PullRequestMatchFirst = "first"
+	PullRequestMatchAll   = "all"
+	PullRequestMatchNone  = "none"
+
+	TargetIssue  = "issue"
+	TargetCommit = "commit"
+
+	ModeComment       = "comment"
+	ModeDelete        = "delete"
+	ModeDeleteOnEmpty = "delete-on-empty"
+
+	// modeFind only looks up the keyed comment and is used by the find command.
+	modeFind = "find"
+
+	HideClassifierOutdated  = "OUTDATED"
+	HideClassifierResolved  = "RESOLVED"
+	HideClassifierDuplicate = "DUPLICATE"
+	HideClassifierOffTopic  = "OFF_TOPIC"
+)
+
+var (
+	ErrPluginEventNotSupported   = errors.New("event not supported")
+	ErrInvalidPullRequestMatch   = errors.New("invalid pull request match")
+	ErrPullRequestNumberNotFound = errors.New("pull request number not found")
+	ErrInvalidIssueNumber        = errors.New("issue number must be greater than 0")
+	ErrIssueNumberConflict       = errors.New("'issue-number' and 'issue-number-from-file' are mutually exclusive")
+	ErrInvalidTarget             = errors.New("invalid target")
+	ErrCommitSHANotFound         = errors.New("commit sha not found")
+	ErrInvalidMode               = errors.New("invalid mode")
+	ErrMessageRequired           = errors.New("message is required")
+	ErrInvalidHideClassifier     = errors.New("invalid hide classifier")
+	ErrHidePreviousNotSupported  = errors.New("'hide-previous' is only supported for issue targets")
+	ErrHidePreviousConflict      = errors.New("'hide-previous' and 'update' are mutually exclusive")
+	ErrInvalidUpdateMode         = errors.New("invalid update mode")
+	ErrUpdateModeRequiresUpdate  = errors.New("'update-mode' append and prepend require 'update'")
+	ErrAuthRequired              = errors.New("either 'api-key' or 'app-id' and 'app-private-key' are required")
+	ErrAuthConflict              = errors.New("'api-key' and 'app-id' are mutually exclusive")
+	ErrSectionNotSupported       = errors.New("'section' is only supported for issue targets")
+	ErrSectionOptionsConflict    = errors.New("'section' can not be combined with 'hide-previous' or 'update-mode'")
+	ErrInvalidOverflow           = errors.New("invalid overflow policy")
+	ErrSplitNotSupported         = errors.New("'overflow: split' is only supported for issue targets")
+	ErrSplitOptionsConflict      = errors.New("'overflow: split' can not be combined with 'section' or 'update-mode'")
+	ErrGistNotSupported          = errors.New("'overflow: gist' is only supported for the github forge")
+	ErrGistOptionsConflict       = errors.New("'overflow: gist' can not be combined with 'section' or 'update-mode'")
+	ErrGistAppNotSupported       = errors.New("'overflow: gist' requires 'api-key', app tokens can not create gists")
+	ErrInvalidForge              = errors.New("invalid forge")
+	ErrForgeNotSupported         = errors.New("option is only supported for github")
+	ErrBaseURLRequired           = errors.New("'base-url' is required if it can not be derived from the repository url")
+	ErrReportNotFound            = errors.New("no report file matches the pattern")
+)
 
 //nolint:revive
 func (p *Plugin) run(ctx context.Context) error {
@@ -32,16 +94,147 @@ func (p *Plugin) run(ctx context.Context) error {
 func (p *Plugin) Validate() error {
 	var err error
 
-	if p.Metadata.Pipeline.Event != "pull_request" {
-		return fmt.Errorf("%w: %s", ErrPluginEventNotSupported, p.Metadata.Pipeline.Event)
+	switch {
+	case p.Settings.APIKey != "" && p.Settings.AppID != 0:
+		return ErrAuthConflict
+	case p.offline():
+		// A dry run without credentials does not send any requests.
+	case p.Settings.APIKey == "" && (p.Settings.AppID == 0 || p.Settings.AppKey == ""):
+		return ErrAuthRequired
 	}
 
-	if p.Settings.Message != "" {
-		if p.Settings.Message, p.Settings.IsFile, err = plugin_file.ReadStringOrFile(p.Settings.Message); err != nil {
-			return fmt.Errorf("error while reading %s: %w", p.Settings.Message, err)
+	if p.Settings.AppKey != "" {
+		if p.Settings.AppKey, _, err = plugin_file.ReadStringOrFile(p.Settings.AppKey); err != nil {
+			return fmt.Errorf("error while reading app private key: %w", err)
+		}
+	}
+
+	if p.Settings.IssueNum < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidIssueNumber, p.Settings.IssueNum)
+	}
+
+	if p.Settings.IssueNumFile != "" {
+		if p.Settings.IssueNum != 0 {
+			return ErrIssueNumberConflict
+		}
+
+		if p.Settings.IssueNum, err = readIssueNumber(p.Settings.IssueNumFile); err != nil {
+			return err
 		}
 	}
 
+	switch p.Settings.Target {
+	case TargetIssue:
+		// An explicit issue number is a valid target for any event.
+		if p.Settings.IssueNum == 0 {
+			switch p.Metadata.Pipeline.Event {
+			case EventPullRequest, EventPush, EventTag, EventManual:
+			default:
+				return fmt.Errorf("%w: %s", ErrPluginEventNotSupported, p.Metadata.Pipeline.Event)
+			}
+		}
+	case TargetCommit:
+		if p.Metadata.Curr.SHA == "" {
+			return ErrCommitSHANotFound
+		}
+
+		if p.Settings.HidePrevious {
+			return ErrHidePreviousNotSupported
+		}
+
+		if p.Settings.Section != "" {
+			return ErrSectionNotSupported
+		}
+
+		if p.Settings.Overflow == gh.OverflowSplit {
+			return ErrSplitNotSupported
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidTarget, p.Settings.Target)
+	}
+
+	switch p.Settings.PullRequestMatch {
+	case PullRequestMatchFirst, PullRequestMatchAll, PullRequestMatchNone:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidPullRequestMatch, p.Settings.PullRequestMatch)
+	}
+
+	switch p.Settings.UpdateMode {
+	case gh.UpdateModeReplace:
+	case gh.UpdateModeAppend, gh.UpdateModePrepend:
+		if !p.Settings.Update {
+			return ErrUpdateModeRequiresUpdate
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidUpdateMode, p.Settings.UpdateMode)
+	}
+
+	if p.Settings.HidePrevious && p.Settings.Update {
+		return ErrHidePreviousConflict
+	}
+
+	if p.Settings.Section != "" && (p.Settings.HidePrevious || p.Settings.UpdateMode != gh.UpdateModeReplace) {
+		return ErrSectionOptionsConflict
+	}
+
+	switch p.Settings.Overflow {
+	case gh.OverflowError, gh.OverflowTruncate:
+	case gh.OverflowSplit:
+		if p.Settings.Section != "" || p.Settings.UpdateMode != gh.UpdateModeReplace {
+			return ErrSplitOptionsConflict
+		}
+	case gh.OverflowGist:
+		if p.Settings.Section != "" || p.Settings.UpdateMode != gh.UpdateModeReplace {
+			return ErrGistOptionsConflict
+		}
+
+		if p.Settings.Forge != gh.ForgeGitHub {
+			return ErrGistNotSupported
+		}
+
+		if p.Settings.AppID != 0 {
+			return ErrGistAppNotSupported
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidOverflow, p.Settings.Overflow)
+	}
+
+	p.Settings.HideClassifier = strings.ToUpper(p.Settings.HideClassifier)
+
+	switch p.Settings.HideClassifier {
+	case HideClassifierOutdated, HideClassifierResolved, HideClassifierDuplicate, HideClassifierOffTopic:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidHideClassifier, p.Settings.HideClassifier)
+	}
+
+	if err := p.loadMessage(); err != nil {
+		return err
+	}
+
+	switch p.Settings.Mode {
+	case ModeComment:
+		if p.Settings.Message == "" {
+			return fmt.Errorf("%w: mode %s", ErrMessageRequired, p.Settings.Mode)
+		}
+	case ModeDelete, ModeDeleteOnEmpty, modeFind:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidMode, p.Settings.Mode)
+	}
+
+	switch p.Settings.Forge {
+	case gh.ForgeGitHub:
+	case gh.ForgeGitea, gh.ForgeForgejo:
+		if err := p.validateForge("api/v1/"); err != nil {
+			return err
+		}
+	case gh.ForgeGitLab:
+		if err := p.validateForge("api/v4/"); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidForge, p.Settings.Forge)
+	}
+
 	if !strings.HasSuffix(p.Settings.BaseURL, "/") {
 		p.Settings.BaseURL += "/"
 	}
@@ -51,42 +244,491 @@ func (p *Plugin) Validate() error {
 		return fmt.Errorf("failed to parse base url: %w", err)
 	}
 
-	if p.Settings.Key == "" {
-		key := fmt.Sprintf("%s/%s/%d", p.Metadata.Repository.Owner, p.Metadata.Repository.Name, p.Settings.IssueNum)
-		hash := sha256.Sum256([]byte(key))
-		p.Settings.Key = fmt.Sprintf("%x", hash)
+	if p.Settings.Key != "" {
+		if p.Settings.Key, _, err = plugin_file.ReadStringOrFile(p.Settings.Key); err != nil {
+			return fmt.Errorf("error while reading %s: %w", p.Settings.Key, err)
+		}
 	}
 
-	if p.Settings.Key, _, err = plugin_file.ReadStringOrFile(p.Settings.Key); err != nil {
-		return fmt.Errorf("error while reading %s: %w", p.Settings.Key, err)
+	return nil
+}
+
+// validateForge checks that only options supported by forges other than GitHub are used and
+// derives the API URL with the given API path from the repository URL if no base URL is
+// configured.
+func (p *Plugin) validateForge(apiPath string) error {
+	switch {
+	case p.Settings.AppID != 0:
+		return fmt.Errorf("%w: app-id", ErrForgeNotSupported)
+	case p.Settings.Target == TargetCommit:
+		return fmt.Errorf("%w: target %s", ErrForgeNotSupported, p.Settings.Target)
+	case p.Settings.HidePrevious:
+		return fmt.Errorf("%w: hide-previous", ErrForgeNotSupported)
+	}
+
+	if p.Settings.BaseURL != DefaultBaseURL {
+		return nil
 	}
 
+	baseURL, err := forgeBaseURL(p.Metadata.Repository.URL, p.Metadata.Repository.Slug, apiPath)
+	if err != nil {
+		return err
+	}
+
+	p.Settings.BaseURL = baseURL
+
 	return nil
 }
 
+// forgeBaseURL returns the API URL of the forge that hosts the repository with the given
+// web URL and slug, e.g. https://codeberg.org/api/v1/ for the Gitea repository
+// https://codeberg.org/owner/name.
+func forgeBaseURL(repoURL, slug, apiPath string) (string, error) {
+	if repoURL == "" {
+		return "", ErrBaseURLRequired
+	}
+
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse repository url: %w", err)
+	}
+
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/"+slug) + "/" + apiPath
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String(), nil
+}
+
+// loadMessage reads the message from a file if it is a path, renders it as template
+// if templating is enabled and appends the configured reports.
+func (p *Plugin) loadMessage() error {
+	var err error
+
+	if p.Settings.Message != "" {
+		if p.Settings.Message, p.Settings.IsFile, err = plugin_file.ReadStringOrFile(p.Settings.Message); err != nil {
+			return fmt.Errorf("error while reading %s: %w", p.Settings.Message, err)
+		}
+	}
+
+	if p.Settings.Template {
+		if p.Settings.Message, err = p.RenderMessage(p.Settings.Message); err != nil {
+			return err
+		}
+	}
+
+	return p.renderReports()
+}
+
 // Execute provides the implementation of the plugin.
 func (p *Plugin) Execute() error {
-	client := gh.NewClient(p.Network.Context, p.Settings.baseURL, p.Settings.APIKey, p.Network.Client)
-	client.Issue.Opt = gh.IssueOptions{
-		Repo:    p.Metadata.Repository.Name,
-		Owner:   p.Metadata.Repository.Owner,
-		Message: p.Settings.Message,
-		Update:  p.Settings.Update,
-		Key:     p.Settings.Key,
-		Number:  p.Metadata.Curr.PullRequest,
+	results, err := p.execute()
+	if err != nil {
+		return err
+	}
+
+	return p.writeOutput(results)
+}
+
+// execute adds, finds or deletes the comment on all targets and returns the results.
+func (p *Plugin) execute() ([]Result, error) {
+	httpClient := p.httpClient()
+
+	ts, err := p.tokenSource(httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var client *gh.Client
+
+	switch p.Settings.Forge {
+	case gh.ForgeGitea, gh.ForgeForgejo:
+		client = gh.NewGiteaClient(p.Network.Context, p.Settings.baseURL, ts, httpClient)
+	case gh.ForgeGitLab:
+		client = gh.NewGitLabClient(p.Network.Context, p.Settings.baseURL, ts, httpClient)
+	default:
+		client = gh.NewClient(p.Network.Context, p.Settings.baseURL, ts, httpClient)
+	}
+
+	if p.Settings.DryRun {
+		client.DryRun(p.offline(), p.stdout)
 	}
 
-	if p.Settings.SkipMissing && !p.Settings.IsFile {
+	var results []Result
+
+	switch {
+	case p.Settings.Mode == ModeComment && p.missingMessage():
 		log.Info().
 			Msg("comment skipped: 'message' is not a valid path or file does not exist while 'skip-missing' is enabled")
 
-		return nil
+		results = []Result{{Action: gh.ActionSkipped}}
+	case p.Settings.Target == TargetCommit:
+		results, err = p.executeCommit(p.Network.Context, client)
+	default:
+		results, err = p.executeIssue(p.Network.Context, client)
+	}
+
+	for idx := range results {
+		results[idx].DryRun = p.Settings.DryRun
+	}
+
+	return results, err
+}
+
+// httpClient returns a copy of the plugin HTTP client that retries failed requests.
+func (p *Plugin) httpClient() *http.Client {
+	client := &http.Client{}
+	if p.Network.Client != nil {
+		*client = *p.Network.Client
 	}
 
-	_, err := client.Issue.AddComment(p.Network.Context)
+	client.Transport = gh.NewRetryTransport(client.Transport, gh.RetryOptions{
+		MaxAttempts: p.Settings.RetryMaxAttempts,
+		Budget:      p.Settings.RetryBudget,
+	})
+
+	return client
+}
+
+// tokenSource returns the token source for the configured authentication method, which
+// is either a personal access token or a GitHub App installation.
+func (p *Plugin) tokenSource(client *http.Client) (oauth2.TokenSource, error) {
+	if p.Settings.AppID == 0 {
+		return gh.NewTokenSource(p.Settings.APIKey), nil
+	}
+
+	ts, err := gh.NewAppTokenSource(p.Network.Context, p.Settings.baseURL, client, gh.AppOptions{
+		AppID:          p.Settings.AppID,
+		InstallationID: p.Settings.AppInstallID,
+		PrivateKey:     []byte(p.Settings.AppKey),
+		Owner:          p.Metadata.Repository.Owner,
+		Repo:           p.Metadata.Repository.Name,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create or update comment: %w", err)
+		return nil, fmt.Errorf("failed to setup github app authentication: %w", err)
 	}
 
-	return nil
+	return ts, nil
+}
+
+// executeIssue adds the comment to all target issues or pull requests.
+func (p *Plugin) executeIssue(ctx context.Context, client *gh.Client) ([]Result, error) {
+	authors, err := p.authors(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	client.Issue.Opt = gh.IssueOptions{
+		Repo:           p.Metadata.Repository.Name,
+		Owner:          p.Metadata.Repository.Owner,
+		Authors:        authors,
+		Metadata:       p.commentMetadata(),
+		LegacyKey:      p.legacyKey(),
+		Message:        p.Settings.Message,
+		Update:         p.Settings.Update,
+		UpdateMode:     p.Settings.UpdateMode,
+		MaxEntries:     p.Settings.MaxEntries,
+		Section:        p.Settings.Section,
+		HidePrevious:   p.Settings.HidePrevious,
+		HideClassifier: p.Settings.HideClassifier,
+		Overflow:       p.Settings.Overflow,
+		OverflowURL:    p.Metadata.Pipeline.URL,
+	}
+
+	numbers, err := p.targets(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(numbers) == 0 {
+		log.Info().
+			Str("event", p.Metadata.Pipeline.Event).
+			Str("commit", p.Metadata.Curr.SHA).
+			Msg("comment skipped: no pull request found to comment on")
+
+		return []Result{{Action: gh.ActionSkipped}}, nil
+	}
+
+	results := make([]Result, 0, len(numbers))
+
+	for _, number := range numbers {
+		client.Issue.Opt.Number = number
+		client.Issue.Opt.Key = p.key(strconv.Itoa(number))
+
+		if p.Settings.Mode == modeFind {
+			comment, err := client.Issue.FindComment(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find comment on #%d: %w", number, err)
+			}
+
+			results = append(results, Result{
+				Number:    number,
+				CommentID: comment.GetID(),
+				HTMLURL:   comment.GetHTMLURL(),
+				Action:    gh.ActionFound,
+			})
+
+			continue
+		}
+
+		if p.deleteComment() {
+			comment, err := client.Issue.DeleteComment(ctx)
+
+			action, err := deleteAction(err)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete comment on #%d: %w", number, err)
+			}
+
+			results = append(results, Result{
+				Number:    number,
+				CommentID: comment.GetID(),
+				HTMLURL:   comment.GetHTMLURL(),
+				Action:    action,
+			})
+
+			continue
+		}
+
+		comment, action, err := client.Issue.AddComment(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create or update comment on #%d: %w", number, err)
+		}
+
+		log.Info().
+			Int("number", number).
+			Str("action", string(action)).
+			Str("url", comment.GetHTMLURL()).
+			Msg(p.actionMessage(action))
+
+		results = append(results, Result{
+			Number:    number,
+			CommentID: comment.GetID(),
+			HTMLURL:   comment.GetHTMLURL(),
+			Action:    action,
+		})
+	}
+
+	return results, nil
+}
+
+// executeCommit adds the comment to the current commit.
+func (p *Plugin) executeCommit(ctx context.Context, client *gh.Client) ([]Result, error) {
+	authors, err := p.authors(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	client.Commit.Opt = gh.CommitOptions{
+		Repo:        p.Metadata.Repository.Name,
+		Owner:       p.Metadata.Repository.Owner,
+		Authors:     authors,
+		Metadata:    p.commentMetadata(),
+		LegacyKey:   p.legacyKey(),
+		SHA:         p.Metadata.Curr.SHA,
+		Message:     p.Settings.Message,
+		Update:      p.Settings.Update,
+		UpdateMode:  p.Settings.UpdateMode,
+		MaxEntries:  p.Settings.MaxEntries,
+		Key:         p.key(p.Metadata.Curr.SHA),
+		Overflow:    p.Settings.Overflow,
+		OverflowURL: p.Metadata.Pipeline.URL,
+	}
+
+	if p.Settings.Mode == modeFind {
+		comment, err := client.Commit.FindComment(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find comment on commit %s: %w", p.Metadata.Curr.SHA, err)
+		}
+
+		return []Result{{
+			Commit:    p.Metadata.Curr.SHA,
+			CommentID: comment.GetID(),
+			HTMLURL:   comment.GetHTMLURL(),
+			Action:    gh.ActionFound,
+		}}, nil
+	}
+
+	if p.deleteComment() {
+		comment, err := client.Commit.DeleteComment(ctx)
+
+		action, err := deleteAction(err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete comment on commit %s: %w", p.Metadata.Curr.SHA, err)
+		}
+
+		return []Result{{
+			Commit:    p.Metadata.Curr.SHA,
+			CommentID: comment.GetID(),
+			HTMLURL:   comment.GetHTMLURL(),
+			Action:    action,
+		}}, nil
+	}
+
+	comment, action, err := client.Commit.AddComment(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or update comment on commit %s: %w", p.Metadata.Curr.SHA, err)
+	}
+
+	log.Info().
+		Str("commit", p.Metadata.Curr.SHA).
+		Str("action", string(action)).
+		Str("url", comment.GetHTMLURL()).
+		Msg(p.actionMessage(action))
+
+	return []Result{{
+		Commit:    p.Metadata.Curr.SHA,
+		CommentID: comment.GetID(),
+		HTMLURL:   comment.GetHTMLURL(),
+		Action:    action,
+	}}, nil
+}
+
+// targets returns the numbers of the issues or pull requests to comment on, derived from
+// the issue number setting or the pipeline event.
+func (p *Plugin) targets(ctx context.Context, client *gh.Client) ([]int, error) {
+	if p.Settings.IssueNum != 0 {
+		return []int{p.Settings.IssueNum}, nil
+	}
+
+	if p.Metadata.Pipeline.Event == EventPullRequest {
+		if p.Metadata.Curr.PullRequest == 0 {
+			return nil, ErrPullRequestNumberNotFound
+		}
+
+		return []int{p.Metadata.Curr.PullRequest}, nil
+	}
+
+	if p.Settings.PullRequestMatch == PullRequestMatchNone {
+		return nil, nil
+	}
+
+	numbers, err := client.Issue.FindPullRequests(ctx, p.Metadata.Curr.SHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull requests for commit %s: %w", p.Metadata.Curr.SHA, err)
+	}
+
+	if p.Settings.PullRequestMatch == PullRequestMatchFirst && len(numbers) > 1 {
+		numbers = numbers[:1]
+	}
+
+	return numbers, nil
+}
+
+// authors returns the logins of the authenticated user and all trusted authors if the
+// comment search is restricted to own comments, or nil otherwise.
+func (p *Plugin) authors(ctx context.Context, client *gh.Client) ([]string, error) {
+	if !p.Settings.OwnCommentsOnly || p.offline() {
+		return nil, nil
+	}
+
+	login, err := client.Login(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve comment author: %w", err)
+	}
+
+	return append([]string{login}, p.Settings.TrustedAuthors.Value()...), nil
+}
+
+// commentMetadata returns the metadata of the current pipeline that is stored in the
+// hidden metadata block of the comment.
+func (p *Plugin) commentMetadata() gh.Metadata {
+	return gh.Metadata{
+		Pipeline:      p.Metadata.Pipeline.Number,
+		Commit:        p.Metadata.Curr.SHA,
+		Timestamp:     time.Now().UTC(),
+		PluginVersion: p.App.Version,
+	}
+}
+
+// actionMessage returns the log message for the comment action, which is prefixed in dry
+// run mode as no request was sent.
+func (p *Plugin) actionMessage(action gh.Action) string {
+	if p.Settings.DryRun {
+		return "dry run: comment " + string(action)
+	}
+
+	return "comment " + string(action)
+}
+
+// offline reports whether the plugin runs in dry run mode without credentials, in which
+// case no requests are sent to GitHub.
+func (p *Plugin) offline() bool {
+	return p.Settings.DryRun && p.Settings.APIKey == "" && p.Settings.AppID == 0
+}
+
+// deleteComment reports whether the keyed comment should be deleted instead of added.
+func (p *Plugin) deleteComment() bool {
+	switch p.Settings.Mode {
+	case ModeDelete:
+		return true
+	case ModeDeleteOnEmpty:
+		return strings.TrimSpace(p.Settings.Message) == "" || p.missingMessage()
+	}
+
+	return false
+}
+
+// missingMessage reports whether the message file does not exist while 'skip-missing' is enabled.
+func (p *Plugin) missingMessage() bool {
+	return p.Settings.SkipMissing && !p.Settings.IsFile
+}
+
+// deleteAction returns the action for the result of a comment deletion. Deleting a comment
+// that does not exist is a no-op, so it is logged and reported as skipped. All other errors
+// are returned as is.
+func deleteAction(err error) (gh.Action, error) {
+	if errors.Is(err, gh.ErrCommentNotFound) {
+		log.Info().Msg("comment deletion skipped: no comment found that matches the key")
+
+		return gh.ActionSkipped, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return gh.ActionDeleted, nil
+}
+
+// key returns the comment key for the given target, which is either an issue number or
+// a commit SHA. If no key is configured, a default key is derived from the repository
+// and the target.
+func (p *Plugin) key(target string) string {
+	if p.Settings.Key != "" {
+		return p.Settings.Key
+	}
+
+	key := fmt.Sprintf("%s/%s/%s", p.Metadata.Repository.Owner, p.Metadata.Repository.Name, target)
+	hash := sha256.Sum256([]byte(key))
+
+	return fmt.Sprintf("%x", hash)
+}
+
+// legacyKey returns the default key of comments written by older versions of the plugin, which
+// was derived from the repository only, or an empty string if a key is configured.
+func (p *Plugin) legacyKey() string {
+	if p.Settings.Key != "" {
+		return ""
+	}
+
+	return p.key("0")
+}
+
+// readIssueNumber reads an issue number from the given file.
+func readIssueNumber(path string) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("error while reading %s: %w", path, err)
+	}
+
+	number, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse issue number from %s: %w", path, err)
+	}
+
+	if number <= 0 {
+		return 0, fmt.Errorf("%w: %d in %s", ErrInvalidIssueNumber, number, path)
+	}
+
+	return number, nil
 }
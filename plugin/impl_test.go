@@ -0,0 +1,589 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gh "github.com/thegeeklab/wp-github-comment/github"
+)
+
+const testRepoPath = "/repos/octocat/hello-world"
+
+// defaultSettings returns the settings with the default values of all flags.
+func defaultSettings(t *testing.T) *Settings {
+	t.Helper()
+
+	settings := &Settings{}
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	for _, f := range Flags(settings, "") {
+		require.NoError(t, f.Apply(set))
+	}
+
+	return settings
+}
+
+// newTestPlugin returns a plugin with default settings that comments on pull request #1 of
+// octocat/hello-world through a test server with the given handler.
+func newTestPlugin(t *testing.T, handler http.Handler) *Plugin {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	p := New(nil)
+	p.Network.Context = context.Background()
+	p.Network.Client = server.Client()
+	p.Metadata.Repository.Owner = "octocat"
+	p.Metadata.Repository.Name = "hello-world"
+	p.Metadata.Repository.Slug = "octocat/hello-world"
+	p.Metadata.Pipeline.Event = EventPullRequest
+	p.Metadata.Curr.PullRequest = 1
+
+	p.Settings = defaultSettings(t)
+	p.Settings.APIKey = "token"
+	p.Settings.BaseURL = server.URL + "/"
+	p.Settings.Message = "test message"
+
+	return p
+}
+
+// writeJSON writes the given value as JSON response.
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
+
+func TestForgeBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoURL string
+		apiPath string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "root",
+			repoURL: "https://codeberg.org/octocat/hello-world",
+			apiPath: "api/v1/",
+			want:    "https://codeberg.org/api/v1/",
+		},
+		{
+			name:    "sub path",
+			repoURL: "https://example.com/gitea/octocat/hello-world/",
+			apiPath: "api/v1/",
+			want:    "https://example.com/gitea/api/v1/",
+		},
+		{
+			name:    "gitlab",
+			repoURL: "https://gitlab.com/octocat/hello-world",
+			apiPath: "api/v4/",
+			want:    "https://gitlab.com/api/v4/",
+		},
+		{
+			name:    "missing",
+			apiPath: "api/v1/",
+			wantErr: ErrBaseURLRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := forgeBaseURL(tt.repoURL, "octocat/hello-world", tt.apiPath)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPlugin_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(s *Settings)
+		wantErr error
+	}{
+		{
+			name:  "valid",
+			setup: func(_ *Settings) {},
+		},
+		{
+			name:    "negative issue number",
+			setup:   func(s *Settings) { s.IssueNum = -1 },
+			wantErr: ErrInvalidIssueNumber,
+		},
+		{
+			name:    "auth required",
+			setup:   func(s *Settings) { s.APIKey = "" },
+			wantErr: ErrAuthRequired,
+		},
+		{
+			name: "app without private key",
+			setup: func(s *Settings) {
+				s.APIKey = ""
+				s.AppID = 1
+			},
+			wantErr: ErrAuthRequired,
+		},
+		{
+			name:    "auth conflict",
+			setup:   func(s *Settings) { s.AppID = 1 },
+			wantErr: ErrAuthConflict,
+		},
+		{
+			name: "dry run without auth",
+			setup: func(s *Settings) {
+				s.APIKey = ""
+				s.DryRun = true
+			},
+		},
+		{
+			name:  "commit target",
+			setup: func(s *Settings) { s.Target = TargetCommit },
+		},
+		{
+			name:    "invalid target",
+			setup:   func(s *Settings) { s.Target = "repo" },
+			wantErr: ErrInvalidTarget,
+		},
+		{
+			name: "hide previous on commit",
+			setup: func(s *Settings) {
+				s.Target = TargetCommit
+				s.HidePrevious = true
+			},
+			wantErr: ErrHidePreviousNotSupported,
+		},
+		{
+			name: "section on commit",
+			setup: func(s *Settings) {
+				s.Target = TargetCommit
+				s.Section = "test"
+			},
+			wantErr: ErrSectionNotSupported,
+		},
+		{
+			name:    "invalid pull request match",
+			setup:   func(s *Settings) { s.PullRequestMatch = "last" },
+			wantErr: ErrInvalidPullRequestMatch,
+		},
+		{
+			name:    "invalid update mode",
+			setup:   func(s *Settings) { s.UpdateMode = "merge" },
+			wantErr: ErrInvalidUpdateMode,
+		},
+		{
+			name:    "invalid mode",
+			setup:   func(s *Settings) { s.Mode = "upsert" },
+			wantErr: ErrInvalidMode,
+		},
+		{
+			name:    "message required",
+			setup:   func(s *Settings) { s.Message = "" },
+			wantErr: ErrMessageRequired,
+		},
+		{
+			name: "delete without message",
+			setup: func(s *Settings) {
+				s.Mode = ModeDelete
+				s.Message = ""
+			},
+		},
+		{
+			name:    "invalid hide classifier",
+			setup:   func(s *Settings) { s.HideClassifier = "SPAM" },
+			wantErr: ErrInvalidHideClassifier,
+		},
+		{
+			name:    "invalid overflow",
+			setup:   func(s *Settings) { s.Overflow = "drop" },
+			wantErr: ErrInvalidOverflow,
+		},
+		{
+			name:  "split overflow",
+			setup: func(s *Settings) { s.Overflow = gh.OverflowSplit },
+		},
+		{
+			name: "split overflow on commit",
+			setup: func(s *Settings) {
+				s.Overflow = gh.OverflowSplit
+				s.Target = TargetCommit
+			},
+			wantErr: ErrSplitNotSupported,
+		},
+		{
+			name: "split overflow with section",
+			setup: func(s *Settings) {
+				s.Overflow = gh.OverflowSplit
+				s.Section = "test"
+			},
+			wantErr: ErrSplitOptionsConflict,
+		},
+		{
+			name: "split overflow with append",
+			setup: func(s *Settings) {
+				s.Overflow = gh.OverflowSplit
+				s.Update = true
+				s.UpdateMode = gh.UpdateModeAppend
+			},
+			wantErr: ErrSplitOptionsConflict,
+		},
+		{
+			name:  "gitea",
+			setup: func(s *Settings) { s.Forge = gh.ForgeGitea },
+		},
+		{
+			name:    "invalid forge",
+			setup:   func(s *Settings) { s.Forge = "bitbucket" },
+			wantErr: ErrInvalidForge,
+		},
+		{
+			name: "gitea with app",
+			setup: func(s *Settings) {
+				s.Forge = gh.ForgeGitea
+				s.APIKey = ""
+				s.AppID = 1
+				s.AppKey = "private-key"
+			},
+			wantErr: ErrForgeNotSupported,
+		},
+		{
+			name: "gitea with commit target",
+			setup: func(s *Settings) {
+				s.Forge = gh.ForgeGitea
+				s.Target = TargetCommit
+			},
+			wantErr: ErrForgeNotSupported,
+		},
+		{
+			name: "gitlab with hide previous",
+			setup: func(s *Settings) {
+				s.Forge = gh.ForgeGitLab
+				s.HidePrevious = true
+			},
+			wantErr: ErrForgeNotSupported,
+		},
+		{
+			name:  "hide previous",
+			setup: func(s *Settings) { s.HidePrevious = true },
+		},
+		{
+			name: "hide previous with update",
+			setup: func(s *Settings) {
+				s.HidePrevious = true
+				s.Update = true
+			},
+			wantErr: ErrHidePreviousConflict,
+		},
+		{
+			name:  "gist overflow",
+			setup: func(s *Settings) { s.Overflow = gh.OverflowGist },
+		},
+		{
+			name: "gist overflow with app",
+			setup: func(s *Settings) {
+				s.Overflow = gh.OverflowGist
+				s.APIKey = ""
+				s.AppID = 1
+				s.AppKey = "private-key"
+			},
+			wantErr: ErrGistAppNotSupported,
+		},
+		{
+			name: "append",
+			setup: func(s *Settings) {
+				s.Update = true
+				s.UpdateMode = gh.UpdateModeAppend
+			},
+		},
+		{
+			name:    "append without update",
+			setup:   func(s *Settings) { s.UpdateMode = gh.UpdateModeAppend },
+			wantErr: ErrUpdateModeRequiresUpdate,
+		},
+		{
+			name:    "prepend without update",
+			setup:   func(s *Settings) { s.UpdateMode = gh.UpdateModePrepend },
+			wantErr: ErrUpdateModeRequiresUpdate,
+		},
+		{
+			name: "gist overflow with append",
+			setup: func(s *Settings) {
+				s.Overflow = gh.OverflowGist
+				s.Update = true
+				s.UpdateMode = gh.UpdateModeAppend
+			},
+			wantErr: ErrGistOptionsConflict,
+		},
+		{
+			name: "gist overflow with gitea",
+			setup: func(s *Settings) {
+				s.Overflow = gh.OverflowGist
+				s.Forge = gh.ForgeGitea
+			},
+			wantErr: ErrGistNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(nil)
+			p.Metadata.Repository.URL = "https://codeberg.org/octocat/hello-world"
+			p.Metadata.Repository.Slug = "octocat/hello-world"
+			p.Metadata.Curr.SHA = "abc123"
+			p.Settings = &Settings{
+				APIKey:           "token",
+				Target:           TargetIssue,
+				IssueNum:         1,
+				PullRequestMatch: PullRequestMatchFirst,
+				UpdateMode:       gh.UpdateModeReplace,
+				Overflow:         gh.OverflowTruncate,
+				HideClassifier:   HideClassifierOutdated,
+				Mode:             ModeComment,
+				Message:          "test message",
+				Forge:            gh.ForgeGitHub,
+				BaseURL:          DefaultBaseURL,
+			}
+
+			tt.setup(p.Settings)
+
+			assert.ErrorIs(t, p.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestReadIssueNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr error
+	}{
+		{name: "number", content: "42\n", want: 42},
+		{name: "zero", content: "0", wantErr: ErrInvalidIssueNumber},
+		{name: "negative", content: "-1", wantErr: ErrInvalidIssueNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "issue")
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			got, err := readIssueNumber(path)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPlugin_Execute_OwnCommentsOnly(t *testing.T) {
+	var created bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, map[string]any{"login": "bot"})
+	})
+
+	p := newTestPlugin(t, mux)
+	key := p.key("1")
+
+	// The comment of another user carries the key and must not be edited.
+	mux.HandleFunc("GET "+testRepoPath+"/issues/1/comments", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, []map[string]any{
+			{"id": 1, "body": "<!-- id: " + key + " -->", "user": map[string]any{"login": "other"}},
+		})
+	})
+	mux.HandleFunc("POST "+testRepoPath+"/issues/1/comments", func(w http.ResponseWriter, _ *http.Request) {
+		created = true
+
+		writeJSON(t, w, map[string]any{"id": 2})
+	})
+
+	p.Settings.Update = true
+
+	require.NoError(t, p.Validate())
+
+	results, err := p.execute()
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, []Result{{Number: 1, CommentID: 2, Action: gh.ActionCreated}}, results)
+}
+
+func TestPlugin_Execute_DryRun(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, map[string]any{"login": "bot"})
+	})
+	mux.HandleFunc("GET "+testRepoPath+"/issues/1/comments", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, []map[string]any{})
+	})
+
+	p := newTestPlugin(t, mux)
+	p.stdout = &bytes.Buffer{}
+	p.Settings.DryRun = true
+
+	require.NoError(t, p.Validate())
+
+	results, err := p.execute()
+	assert.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, gh.ActionCreated, results[0].Action)
+	assert.True(t, results[0].DryRun)
+}
+
+func TestPlugin_Execute_Delete(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		message     string
+		skipMissing bool
+		comments    bool
+		want        gh.Action
+	}{
+		{name: "delete", mode: ModeDelete, comments: true, want: gh.ActionDeleted},
+		{name: "delete missing comment", mode: ModeDelete, want: gh.ActionSkipped},
+		{name: "delete on empty", mode: ModeDeleteOnEmpty, comments: true, want: gh.ActionDeleted},
+		{name: "delete on empty missing comment", mode: ModeDeleteOnEmpty, want: gh.ActionSkipped},
+		{name: "delete on empty message", mode: ModeDeleteOnEmpty, message: "test message", want: gh.ActionCreated},
+		{
+			name:        "delete on empty missing file",
+			mode:        ModeDeleteOnEmpty,
+			message:     "missing.md",
+			skipMissing: true,
+			comments:    true,
+			want:        gh.ActionDeleted,
+		},
+		{name: "skip missing file", mode: ModeComment, message: "missing.md", skipMissing: true, want: gh.ActionSkipped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted bool
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /user", func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(t, w, map[string]any{"login": "bot"})
+			})
+
+			p := newTestPlugin(t, mux)
+			p.Settings.Mode = tt.mode
+			p.Settings.Message = tt.message
+			p.Settings.SkipMissing = tt.skipMissing
+
+			mux.HandleFunc("GET "+testRepoPath+"/issues/1/comments", func(w http.ResponseWriter, _ *http.Request) {
+				comments := []map[string]any{}
+				if tt.comments {
+					comments = append(comments, map[string]any{
+						"id": 1, "body": "<!-- id: " + p.key("1") + " -->", "user": map[string]any{"login": "bot"},
+					})
+				}
+
+				writeJSON(t, w, comments)
+			})
+			mux.HandleFunc("POST "+testRepoPath+"/issues/1/comments", func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(t, w, map[string]any{"id": 2})
+			})
+			mux.HandleFunc("DELETE "+testRepoPath+"/issues/comments/1", func(w http.ResponseWriter, _ *http.Request) {
+				deleted = true
+
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			require.NoError(t, p.Validate())
+
+			results, err := p.execute()
+			assert.NoError(t, err)
+			assert.Len(t, results, 1)
+			assert.Equal(t, tt.want, results[0].Action)
+			assert.Equal(t, tt.want == gh.ActionDeleted, deleted)
+		})
+	}
+}
+
+func TestPlugin_Targets(t *testing.T) {
+	tests := []struct {
+		name        string
+		event       string
+		pullRequest int
+		issueNum    int
+		match       string
+		want        []int
+		wantErr     error
+	}{
+		{name: "pull request", event: EventPullRequest, pullRequest: 1, want: []int{1}},
+		{name: "pull request without number", event: EventPullRequest, wantErr: ErrPullRequestNumberNotFound},
+		{name: "push first", event: EventPush, match: PullRequestMatchFirst, want: []int{3}},
+		{name: "push all", event: EventPush, match: PullRequestMatchAll, want: []int{3, 4}},
+		{name: "push none", event: EventPush, match: PullRequestMatchNone},
+		{name: "tag first", event: EventTag, match: PullRequestMatchFirst, want: []int{3}},
+		{name: "tag all", event: EventTag, match: PullRequestMatchAll, want: []int{3, 4}},
+		{name: "manual first", event: EventManual, match: PullRequestMatchFirst, want: []int{3}},
+		{name: "manual none", event: EventManual, match: PullRequestMatchNone},
+		{name: "issue number", event: EventPullRequest, pullRequest: 1, issueNum: 7, want: []int{7}},
+		{name: "issue number on push", event: EventPush, issueNum: 7, match: PullRequestMatchAll, want: []int{7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET "+testRepoPath+"/commits/abc123/pulls", func(w http.ResponseWriter, _ *http.Request) {
+				assert.NotEqual(t, PullRequestMatchNone, tt.match)
+
+				writeJSON(t, w, []map[string]any{{"number": 3}, {"number": 4}})
+			})
+
+			p := newTestPlugin(t, mux)
+			p.Metadata.Pipeline.Event = tt.event
+			p.Metadata.Curr.PullRequest = tt.pullRequest
+			p.Metadata.Curr.SHA = "abc123"
+			p.Settings.IssueNum = tt.issueNum
+
+			if tt.match != "" {
+				p.Settings.PullRequestMatch = tt.match
+			}
+
+			require.NoError(t, p.Validate())
+
+			client := gh.NewClient(p.Network.Context, p.Settings.baseURL, gh.NewTokenSource("token"), p.Network.Client)
+			client.Issue.Opt = gh.IssueOptions{Owner: "octocat", Repo: "hello-world"}
+
+			got, err := p.targets(context.Background(), client)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPlugin_Validate_Event(t *testing.T) {
+	p := newTestPlugin(t, http.NewServeMux())
+	p.Metadata.Pipeline.Event = "cron"
+
+	assert.ErrorIs(t, p.Validate(), ErrPluginEventNotSupported)
+
+	// An explicit issue number is a valid target for any event.
+	p.Settings.IssueNum = 7
+
+	assert.NoError(t, p.Validate())
+}
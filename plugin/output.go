@@ -0,0 +1,103 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gh "github.com/thegeeklab/wp-github-comment/github"
+)
+
+const outputFilePerm = 0o644
+
+// Result holds the outcome of the plugin for a single comment target.
+type Result struct {
+	Number    int       `json:"number,omitempty"`
+	Commit    string    `json:"commit,omitempty"`
+	CommentID int64     `json:"comment_id,omitempty"`
+	HTMLURL   string    `json:"html_url,omitempty"`
+	Action    gh.Action `json:"action"`
+	DryRun    bool      `json:"dry_run,omitempty"`
+}
+
+// writeOutput writes the results to the configured output file as JSON or in dotenv format,
+// depending on the file extension.
+func (p *Plugin) writeOutput(results []Result) error {
+	if p.Settings.OutputFile == "" || len(results) == 0 {
+		return nil
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if strings.EqualFold(filepath.Ext(p.Settings.OutputFile), ".json") {
+		data, err = marshalResults(results)
+		if err != nil {
+			return err
+		}
+	} else {
+		data = []byte(dotenv(results))
+	}
+
+	if err := os.WriteFile(p.Settings.OutputFile, data, outputFilePerm); err != nil {
+		return fmt.Errorf("failed to write output file %s: %w", p.Settings.OutputFile, err)
+	}
+
+	return nil
+}
+
+// marshalResults returns the results as indented JSON array.
+func marshalResults(results []Result) ([]byte, error) {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// dotenv returns the results in dotenv format. The keys of the last result are written
+// without suffix, the keys of each result are written with the index as suffix, e.g.
+// `comment_id_0`, so the results of all targets are available.
+func dotenv(results []Result) string {
+	var b strings.Builder
+
+	writeDotenv(&b, results[len(results)-1], "")
+
+	fmt.Fprintf(&b, "count=%d\n", len(results))
+
+	for idx, result := range results {
+		writeDotenv(&b, result, fmt.Sprintf("_%d", idx))
+	}
+
+	return b.String()
+}
+
+// writeDotenv writes the result in dotenv format with the given suffix for all keys.
+func writeDotenv(b *strings.Builder, result Result, suffix string) {
+	fmt.Fprintf(b, "action%s=%s\n", suffix, result.Action)
+
+	if result.Number != 0 {
+		fmt.Fprintf(b, "number%s=%d\n", suffix, result.Number)
+	}
+
+	if result.Commit != "" {
+		fmt.Fprintf(b, "commit%s=%s\n", suffix, result.Commit)
+	}
+
+	if result.CommentID != 0 {
+		fmt.Fprintf(b, "comment_id%s=%d\n", suffix, result.CommentID)
+	}
+
+	if result.HTMLURL != "" {
+		fmt.Fprintf(b, "html_url%s=%s\n", suffix, result.HTMLURL)
+	}
+
+	if result.DryRun {
+		fmt.Fprintf(b, "dry_run%s=true\n", suffix)
+	}
+}
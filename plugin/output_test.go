@@ -0,0 +1,77 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gh "github.com/thegeeklab/wp-github-comment/github"
+)
+
+func TestPlugin_WriteOutput(t *testing.T) {
+	results := []Result{
+		{Number: 1, Action: gh.ActionSkipped},
+		{
+			Number:    2,
+			CommentID: 123,
+			HTMLURL:   "https://github.com/octocat/hello-world/pull/2#issuecomment-123",
+			Action:    gh.ActionCreated,
+		},
+		{Number: 3, Action: gh.ActionCreated, DryRun: true},
+	}
+
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{
+			name: "dotenv",
+			file: "comment.env",
+			want: "action=created\nnumber=3\ndry_run=true\n" +
+				"count=3\n" +
+				"action_0=skipped\nnumber_0=1\n" +
+				"action_1=created\nnumber_1=2\ncomment_id_1=123\n" +
+				"html_url_1=https://github.com/octocat/hello-world/pull/2#issuecomment-123\n" +
+				"action_2=created\nnumber_2=3\ndry_run_2=true\n",
+		},
+		{
+			name: "json",
+			file: "comment.json",
+			want: `[
+  {
+    "number": 1,
+    "action": "skipped"
+  },
+  {
+    "number": 2,
+    "comment_id": 123,
+    "html_url": "https://github.com/octocat/hello-world/pull/2#issuecomment-123",
+    "action": "created"
+  },
+  {
+    "number": 3,
+    "action": "created",
+    "dry_run": true
+  }
+]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+
+			p := New(nil)
+			p.Settings.OutputFile = path
+
+			assert.NoError(t, p.writeOutput(results))
+
+			got, err := os.ReadFile(path)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -2,9 +2,14 @@ package plugin
 
 import (
 	"fmt"
+	"io"
 	"net/url"
+	"os"
+	"time"
 
+	gh "github.com/thegeeklab/wp-github-comment/github"
 	plugin_base "github.com/thegeeklab/wp-plugin-go/v4/plugin"
+	plugin_types "github.com/thegeeklab/wp-plugin-go/v4/types"
 	"github.com/urfave/cli/v2"
 )
 
@@ -14,18 +19,50 @@ import (
 type Plugin struct {
 	*plugin_base.Plugin
 	Settings *Settings
+
+	// stdout receives the output of the find and render commands.
+	stdout io.Writer
 }
 
 // Settings for the Plugin.
 type Settings struct {
-	BaseURL     string
-	IssueNum    int
-	Key         string
-	Message     string
-	Update      bool
-	APIKey      string
-	SkipMissing bool
-	IsFile      bool
+	BaseURL      string
+	IssueNum     int
+	IssueNumFile string
+	Key          string
+	Message      string
+	Update       bool
+	APIKey       string
+	AppID        int64
+	AppInstallID int64
+	AppKey       string
+	SkipMissing  bool
+	IsFile       bool
+	Template     bool
+	TemplateVars plugin_types.MapFlag
+
+	PullRequestMatch string
+	Target           string
+	Forge            string
+	Mode             string
+	UpdateMode       string
+	MaxEntries       int
+	Section          string
+	OwnCommentsOnly  bool
+	TrustedAuthors   cli.StringSlice
+	ReportJUnit      cli.StringSlice
+	ReportCoverage   string
+	CoverageBaseline string
+	ReportSARIF      cli.StringSlice
+	ReportTerraform  string
+	TerraformText    string
+	OutputFile       string
+	Overflow         string
+	DryRun           bool
+	RetryMaxAttempts int
+	RetryBudget      time.Duration
+	HidePrevious     bool
+	HideClassifier   string
 
 	baseURL *url.URL
 }
@@ -33,6 +70,7 @@ type Settings struct {
 func New(e plugin_base.ExecuteFunc, build ...string) *Plugin {
 	p := &Plugin{
 		Settings: &Settings{},
+		stdout:   os.Stdout,
 	}
 
 	options := plugin_base.Options{
@@ -56,6 +94,7 @@ func New(e plugin_base.ExecuteFunc, build ...string) *Plugin {
 	}
 
 	p.Plugin = plugin_base.New(options)
+	p.App.Commands = p.Commands()
 
 	return p
 }
@@ -69,16 +108,66 @@ func Flags(settings *Settings, category string) []cli.Flag {
 			Usage:       "personal access token to access the GitHub API",
 			Destination: &settings.APIKey,
 			Category:    category,
-			Required:    true,
+		},
+		&cli.Int64Flag{
+			Name:        "app-id",
+			EnvVars:     []string{"PLUGIN_APP_ID", "GITHUB_COMMENT_APP_ID"},
+			Usage:       "GitHub App ID to authenticate as app installation instead of using a personal access token",
+			Destination: &settings.AppID,
+			Category:    category,
+		},
+		&cli.Int64Flag{
+			Name:        "app-installation-id",
+			EnvVars:     []string{"PLUGIN_APP_INSTALLATION_ID", "GITHUB_COMMENT_APP_INSTALLATION_ID"},
+			Usage:       "GitHub App installation ID, discovered from the repository if not set",
+			Destination: &settings.AppInstallID,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "app-private-key",
+			EnvVars:     []string{"PLUGIN_APP_PRIVATE_KEY", "GITHUB_COMMENT_APP_PRIVATE_KEY"},
+			Usage:       "path to file or string that contains the PEM encoded GitHub App private key",
+			Destination: &settings.AppKey,
+			Category:    category,
 		},
 		&cli.StringFlag{
 			Name:        "base-url",
 			EnvVars:     []string{"PLUGIN_BASE_URL", "GITHUB_COMMENT_BASE_URL"},
 			Usage:       "API URL",
-			Value:       "https://api.github.com/",
+			Value:       DefaultBaseURL,
 			Destination: &settings.BaseURL,
 			Category:    category,
 		},
+		&cli.StringFlag{
+			Name:        "forge",
+			EnvVars:     []string{"PLUGIN_FORGE", "GITHUB_COMMENT_FORGE", "CI_FORGE_TYPE"},
+			Usage:       "forge that hosts the repository, one of github, gitea, forgejo or gitlab",
+			Value:       gh.ForgeGitHub,
+			Destination: &settings.Forge,
+			Category:    category,
+		},
+		&cli.IntFlag{
+			Name:        "issue-number",
+			EnvVars:     []string{"PLUGIN_ISSUE_NUMBER", "GITHUB_COMMENT_ISSUE_NUMBER"},
+			Usage:       "number of the issue or pull request to comment on",
+			Destination: &settings.IssueNum,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "issue-number-from-file",
+			EnvVars:     []string{"PLUGIN_ISSUE_NUMBER_FROM_FILE", "GITHUB_COMMENT_ISSUE_NUMBER_FROM_FILE"},
+			Usage:       "path to file that contains the number of the issue or pull request to comment on",
+			Destination: &settings.IssueNumFile,
+			Category:    category,
+		},
+		&cli.BoolFlag{
+			Name:        "dry-run",
+			EnvVars:     []string{"PLUGIN_DRY_RUN", "GITHUB_COMMENT_DRY_RUN"},
+			Usage:       "print the intended changes and the comment body diff instead of writing to github",
+			Value:       false,
+			Destination: &settings.DryRun,
+			Category:    category,
+		},
 		&cli.StringFlag{
 			Name:        "key",
 			EnvVars:     []string{"PLUGIN_KEY", "GITHUB_COMMENT_KEY"},
@@ -92,7 +181,21 @@ func Flags(settings *Settings, category string) []cli.Flag {
 			Usage:       "path to file or string that contains the comment text",
 			Destination: &settings.Message,
 			Category:    category,
-			Required:    true,
+		},
+		&cli.BoolFlag{
+			Name:        "template",
+			EnvVars:     []string{"PLUGIN_TEMPLATE", "GITHUB_COMMENT_TEMPLATE"},
+			Usage:       "render the message as Go template with access to the pipeline metadata",
+			Value:       false,
+			Destination: &settings.Template,
+			Category:    category,
+		},
+		&cli.GenericFlag{
+			Name:     "template-vars",
+			EnvVars:  []string{"PLUGIN_TEMPLATE_VARS", "GITHUB_COMMENT_TEMPLATE_VARS"},
+			Usage:    "additional variables for the message template as JSON object",
+			Value:    &settings.TemplateVars,
+			Category: category,
 		},
 		&cli.BoolFlag{
 			Name:        "update",
@@ -102,6 +205,53 @@ func Flags(settings *Settings, category string) []cli.Flag {
 			Destination: &settings.Update,
 			Category:    category,
 		},
+		&cli.StringFlag{
+			Name:        "update-mode",
+			EnvVars:     []string{"PLUGIN_UPDATE_MODE", "GITHUB_COMMENT_UPDATE_MODE"},
+			Usage:       "update strategy for an existing comment, one of replace, append or prepend",
+			Value:       gh.UpdateModeReplace,
+			Destination: &settings.UpdateMode,
+			Category:    category,
+		},
+		&cli.IntFlag{
+			Name:        "max-entries",
+			EnvVars:     []string{"PLUGIN_MAX_ENTRIES", "GITHUB_COMMENT_MAX_ENTRIES"},
+			Usage:       "maximum number of entries to retain in append or prepend update mode, 0 for unlimited",
+			Value:       0,
+			Destination: &settings.MaxEntries,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "overflow",
+			EnvVars:     []string{"PLUGIN_OVERFLOW", "GITHUB_COMMENT_OVERFLOW"},
+			Usage:       "policy for messages that exceed the maximum comment length, one of error, truncate, split or gist",
+			Value:       gh.OverflowTruncate,
+			Destination: &settings.Overflow,
+			Category:    category,
+		},
+		&cli.IntFlag{
+			Name:        "retry-max-attempts",
+			EnvVars:     []string{"PLUGIN_RETRY_MAX_ATTEMPTS", "GITHUB_COMMENT_RETRY_MAX_ATTEMPTS"},
+			Usage:       "maximum number of attempts for GitHub API requests that fail with transient errors or rate limits",
+			Value:       5,
+			Destination: &settings.RetryMaxAttempts,
+			Category:    category,
+		},
+		&cli.DurationFlag{
+			Name:        "retry-budget",
+			EnvVars:     []string{"PLUGIN_RETRY_BUDGET", "GITHUB_COMMENT_RETRY_BUDGET"},
+			Usage:       "time after the start of a run in which failed GitHub API requests are retried",
+			Value:       5 * time.Minute,
+			Destination: &settings.RetryBudget,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "section",
+			EnvVars:     []string{"PLUGIN_SECTION", "GITHUB_COMMENT_SECTION"},
+			Usage:       "name of the section to update within the comment that matches the key",
+			Destination: &settings.Section,
+			Category:    category,
+		},
 		&cli.BoolFlag{
 			Name:        "skip-missing",
 			EnvVars:     []string{"PLUGIN_SKIP_MISSING", "GITHUB_COMMENT_SKIP_MISSING"},
@@ -110,5 +260,109 @@ func Flags(settings *Settings, category string) []cli.Flag {
 			Destination: &settings.SkipMissing,
 			Category:    category,
 		},
+		&cli.StringFlag{
+			Name:        "mode",
+			EnvVars:     []string{"PLUGIN_MODE", "GITHUB_COMMENT_MODE"},
+			Usage:       "comment mode, one of comment, delete or delete-on-empty",
+			Value:       ModeComment,
+			Destination: &settings.Mode,
+			Category:    category,
+		},
+		&cli.BoolFlag{
+			Name:        "hide-previous",
+			EnvVars:     []string{"PLUGIN_HIDE_PREVIOUS", "GITHUB_COMMENT_HIDE_PREVIOUS"},
+			Usage:       "minimize all previous comments that match the key and create a new comment",
+			Value:       false,
+			Destination: &settings.HidePrevious,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "hide-classifier",
+			EnvVars:     []string{"PLUGIN_HIDE_CLASSIFIER", "GITHUB_COMMENT_HIDE_CLASSIFIER"},
+			Usage:       "reason for minimizing previous comments, e.g. OUTDATED or RESOLVED",
+			Value:       HideClassifierOutdated,
+			Destination: &settings.HideClassifier,
+			Category:    category,
+		},
+		&cli.BoolFlag{
+			Name:        "own-comments-only",
+			EnvVars:     []string{"PLUGIN_OWN_COMMENTS_ONLY", "GITHUB_COMMENT_OWN_COMMENTS_ONLY"},
+			Usage:       "only consider comments authored by the authenticated user or trusted authors",
+			Value:       true,
+			Destination: &settings.OwnCommentsOnly,
+			Category:    category,
+		},
+		&cli.StringSliceFlag{
+			Name:        "trusted-authors",
+			EnvVars:     []string{"PLUGIN_TRUSTED_AUTHORS", "GITHUB_COMMENT_TRUSTED_AUTHORS"},
+			Usage:       "additional comment authors to consider when searching for the key",
+			Destination: &settings.TrustedAuthors,
+			Category:    category,
+		},
+		&cli.StringSliceFlag{
+			Name:        "report-junit",
+			EnvVars:     []string{"PLUGIN_REPORT_JUNIT", "GITHUB_COMMENT_REPORT_JUNIT"},
+			Usage:       "glob patterns of JUnit XML files to render as test report below the message",
+			Destination: &settings.ReportJUnit,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "report-coverage",
+			EnvVars:     []string{"PLUGIN_REPORT_COVERAGE", "GITHUB_COMMENT_REPORT_COVERAGE"},
+			Usage:       "path to Go coverage profile to render as coverage report below the message",
+			Destination: &settings.ReportCoverage,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "report-coverage-baseline",
+			EnvVars:     []string{"PLUGIN_REPORT_COVERAGE_BASELINE", "GITHUB_COMMENT_REPORT_COVERAGE_BASELINE"},
+			Usage:       "path to Go coverage profile of the base branch to compare the coverage report with",
+			Destination: &settings.CoverageBaseline,
+			Category:    category,
+		},
+		&cli.StringSliceFlag{
+			Name:        "report-sarif",
+			EnvVars:     []string{"PLUGIN_REPORT_SARIF", "GITHUB_COMMENT_REPORT_SARIF"},
+			Usage:       "glob patterns of SARIF files to render as findings report below the message",
+			Destination: &settings.ReportSARIF,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "report-terraform",
+			EnvVars:     []string{"PLUGIN_REPORT_TERRAFORM", "GITHUB_COMMENT_REPORT_TERRAFORM"},
+			Usage:       "path to a Terraform or OpenTofu JSON plan to render as plan report below the message",
+			Destination: &settings.ReportTerraform,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "report-terraform-text",
+			EnvVars:     []string{"PLUGIN_REPORT_TERRAFORM_TEXT", "GITHUB_COMMENT_REPORT_TERRAFORM_TEXT"},
+			Usage:       "path to the text output of the plan to show in the plan report",
+			Destination: &settings.TerraformText,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "output-file",
+			EnvVars:     []string{"PLUGIN_OUTPUT_FILE", "GITHUB_COMMENT_OUTPUT_FILE"},
+			Usage:       "path to write the comment ID, URL and action to, in JSON format for .json files or dotenv otherwise",
+			Destination: &settings.OutputFile,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "pull-request-match",
+			EnvVars:     []string{"PLUGIN_PULL_REQUEST_MATCH", "GITHUB_COMMENT_PULL_REQUEST_MATCH"},
+			Usage:       "pull requests to comment on for push, tag and manual events, one of first, all or none",
+			Value:       PullRequestMatchFirst,
+			Destination: &settings.PullRequestMatch,
+			Category:    category,
+		},
+		&cli.StringFlag{
+			Name:        "target",
+			EnvVars:     []string{"PLUGIN_TARGET", "GITHUB_COMMENT_TARGET"},
+			Usage:       "comment target, one of issue (issues and pull requests) or commit",
+			Value:       TargetIssue,
+			Destination: &settings.Target,
+			Category:    category,
+		},
 	}
 }
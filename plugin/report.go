@@ -0,0 +1,216 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	gh "github.com/thegeeklab/wp-github-comment/github"
+	"github.com/thegeeklab/wp-github-comment/report"
+)
+
+// reportReserve is kept free in the comment for the hidden metadata and the overflow footer.
+const reportReserve = 1024
+
+// renderReports renders the configured reports and appends them to the message. Each report
+// is limited to the space that is left in the comment, so the message and the summary of
+// previous reports are never cut off.
+func (p *Plugin) renderReports() error {
+	reports := []func() (string, error){
+		p.junitReport,
+		p.coverageReport,
+		p.sarifReport,
+		p.terraformReport,
+	}
+
+	for _, render := range reports {
+		content, err := render()
+		if err != nil {
+			return err
+		}
+
+		if content == "" {
+			continue
+		}
+
+		if p.Settings.Message != "" {
+			p.Settings.Message += "\n\n"
+		}
+
+		p.Settings.Message += content
+	}
+
+	return nil
+}
+
+// junitReport renders the configured JUnit XML files as test report.
+func (p *Plugin) junitReport() (string, error) {
+	patterns := p.Settings.ReportJUnit.Value()
+	if len(patterns) == 0 {
+		return "", nil
+	}
+
+	paths, err := globFiles(patterns)
+	if err != nil {
+		return "", err
+	}
+
+	junit, err := report.ParseJUnit(paths)
+	if err != nil {
+		return "", err
+	}
+
+	return junit.Markdown(p.reportSize()), nil
+}
+
+// coverageReport renders the configured Go coverage profile as coverage report, including
+// the delta to the baseline profile if available.
+func (p *Plugin) coverageReport() (string, error) {
+	if p.Settings.ReportCoverage == "" {
+		return "", nil
+	}
+
+	coverage, err := report.ParseCoverage(p.Settings.ReportCoverage)
+	if err != nil {
+		return "", err
+	}
+
+	var baseline *report.Coverage
+
+	if p.hasCoverageBaseline() {
+		if baseline, err = report.ParseCoverage(p.Settings.CoverageBaseline); err != nil {
+			return "", err
+		}
+	}
+
+	return coverage.Markdown(baseline, p.reportSize()), nil
+}
+
+// sarifReport renders the configured SARIF files as findings report. File locations are
+// resolved relative to the working directory, which is the repository checkout.
+func (p *Plugin) sarifReport() (string, error) {
+	patterns := p.Settings.ReportSARIF.Value()
+	if len(patterns) == 0 {
+		return "", nil
+	}
+
+	paths, err := globFiles(patterns)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	sarif, err := report.ParseSARIF(paths, root)
+	if err != nil {
+		return "", err
+	}
+
+	return sarif.Markdown(p.fileURL, p.reportSize()), nil
+}
+
+// terraformReport renders the configured JSON plan as plan report, including the text output
+// of the plan if configured.
+func (p *Plugin) terraformReport() (string, error) {
+	if p.Settings.ReportTerraform == "" {
+		return "", nil
+	}
+
+	plan, err := report.ParseTerraform(p.Settings.ReportTerraform)
+	if err != nil {
+		return "", err
+	}
+
+	var output []byte
+
+	if p.Settings.TerraformText != "" {
+		if output, err = os.ReadFile(p.Settings.TerraformText); err != nil {
+			return "", fmt.Errorf("failed to read terraform plan output %s: %w", p.Settings.TerraformText, err)
+		}
+	}
+
+	return plan.Markdown(string(output), p.reportSize()), nil
+}
+
+// fileURL returns the URL of the given line of a file in the repository at the current
+// commit, or an empty string if the repository URL or commit is unknown.
+func (p *Plugin) fileURL(path string, line int) string {
+	repoURL := strings.TrimSuffix(p.Metadata.Repository.URL, "/")
+	if repoURL == "" || p.Metadata.Curr.SHA == "" {
+		return ""
+	}
+
+	tree := "blob"
+
+	switch p.Settings.Forge {
+	case gh.ForgeGitea, gh.ForgeForgejo:
+		tree = "src/commit"
+	case gh.ForgeGitLab:
+		tree = "-/blob"
+	}
+
+	fileURL := fmt.Sprintf("%s/%s/%s/%s", repoURL, tree, p.Metadata.Curr.SHA, (&url.URL{Path: path}).EscapedPath())
+	if line > 0 {
+		fileURL += fmt.Sprintf("#L%d", line)
+	}
+
+	return fileURL
+}
+
+// hasCoverageBaseline reports whether a baseline coverage profile is configured and exists.
+// A missing baseline, e.g. on the first run before the base branch was measured, is not an
+// error, the coverage report is rendered without delta instead.
+func (p *Plugin) hasCoverageBaseline() bool {
+	if p.Settings.CoverageBaseline == "" {
+		return false
+	}
+
+	if _, err := os.Stat(p.Settings.CoverageBaseline); errors.Is(err, fs.ErrNotExist) {
+		log.Warn().
+			Str("path", p.Settings.CoverageBaseline).
+			Msg("coverage baseline not found: delta is not reported")
+
+		return false
+	}
+
+	return true
+}
+
+// reportSize returns the space that is left in the comment for the next report.
+func (p *Plugin) reportSize() int {
+	return max(gh.MaxForgeCommentLength(p.Settings.Forge)-reportReserve-len(p.Settings.Message), 0)
+}
+
+// globFiles returns the files that match the given glob patterns, in the order of the
+// patterns and without duplicates. Each pattern must match at least one file.
+func globFiles(patterns []string) ([]string, error) {
+	var paths []string
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid report pattern %s: %w", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrReportNotFound, pattern)
+		}
+
+		for _, match := range matches {
+			if !slices.Contains(paths, match) {
+				paths = append(paths, match)
+			}
+		}
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,111 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gh "github.com/thegeeklab/wp-github-comment/github"
+	plugin_base "github.com/thegeeklab/wp-plugin-go/v4/plugin"
+	"github.com/urfave/cli/v2"
+)
+
+func TestPlugin_RenderReports(t *testing.T) {
+	dir := t.TempDir()
+	report := `<testsuite name="pkg"><testcase classname="pkg" name="TestPass" time="1"/></testsuite>`
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.xml"), []byte(report), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.xml"), []byte(report), 0o600))
+
+	p := New(nil)
+	p.Settings.Message = "Build finished."
+	assert.NoError(t, p.Settings.ReportJUnit.Set(filepath.Join(dir, "*.xml")))
+
+	assert.NoError(t, p.renderReports())
+	assert.Contains(t, p.Settings.Message, "Build finished.\n\n### :white_check_mark: Test Results\n")
+	assert.Contains(t, p.Settings.Message, "| **Total** | **2** | **0** | **0** | **2s** |")
+
+	p.Settings.ReportJUnit = *cli.NewStringSlice(filepath.Join(dir, "*.json"))
+	assert.ErrorIs(t, p.renderReports(), ErrReportNotFound)
+}
+
+func TestPlugin_RenderReports_Coverage(t *testing.T) {
+	profile := filepath.Join(t.TempDir(), "coverage.out")
+	assert.NoError(t, os.WriteFile(profile, []byte("mode: set\nexample.com/app/a.go:3.10,5.2 2 1\n"), 0o600))
+
+	p := New(nil)
+	p.Settings.ReportCoverage = profile
+	p.Settings.CoverageBaseline = filepath.Join(t.TempDir(), "missing.out")
+
+	assert.NoError(t, p.renderReports())
+	assert.Contains(t, p.Settings.Message, "**Total: 100.0%**\n")
+	assert.Contains(t, p.Settings.Message, "| example.com/app | 100.0% |\n")
+}
+
+func TestPlugin_RenderReports_Terraform(t *testing.T) {
+	dir := t.TempDir()
+	plan := `{"resource_changes": [
+		{"address": "null_resource.a", "type": "null_resource", "change": {"actions": ["create"]}}
+	]}`
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "plan.json"), []byte(plan), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "plan.txt"), []byte("  # null_resource.a will be created\n"), 0o600))
+
+	p := New(nil)
+	p.Settings.ReportTerraform = filepath.Join(dir, "plan.json")
+	p.Settings.TerraformText = filepath.Join(dir, "plan.txt")
+
+	assert.NoError(t, p.renderReports())
+	assert.Contains(t, p.Settings.Message, "**Plan: 1 to add, 0 to change, 0 to destroy.**\n")
+	assert.Contains(t, p.Settings.Message, "```\n# null_resource.a will be created\n```")
+
+	p.Settings.TerraformText = filepath.Join(dir, "missing.txt")
+	assert.Error(t, p.renderReports())
+}
+
+func TestPlugin_FileURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		forge string
+		path  string
+		line  int
+		want  string
+	}{
+		{
+			name:  "github",
+			forge: gh.ForgeGitHub,
+			path:  "plugin/impl.go",
+			line:  42,
+			want:  "https://example.com/octocat/hello-world/blob/4b825dc/plugin/impl.go#L42",
+		},
+		{
+			name:  "gitea",
+			forge: gh.ForgeGitea,
+			path:  "my file.go",
+			want:  "https://example.com/octocat/hello-world/src/commit/4b825dc/my%20file.go",
+		},
+		{
+			name:  "gitlab",
+			forge: gh.ForgeGitLab,
+			path:  "main.go",
+			line:  1,
+			want:  "https://example.com/octocat/hello-world/-/blob/4b825dc/main.go#L1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(nil)
+			p.Settings.Forge = tt.forge
+			p.Metadata = plugin_base.Metadata{
+				Repository: plugin_base.Repository{URL: "https://example.com/octocat/hello-world/"},
+				Curr:       plugin_base.Commit{SHA: "4b825dc"},
+			}
+
+			assert.Equal(t, tt.want, p.fileURL(tt.path, tt.line))
+		})
+	}
+
+	assert.Empty(t, New(nil).fileURL("main.go", 1))
+}
@@ -0,0 +1,53 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	plugin_base "github.com/thegeeklab/wp-plugin-go/v4/plugin"
+	plugin_template "github.com/thegeeklab/wp-plugin-go/v4/template"
+)
+
+// TemplateData holds the data that is accessible in message templates.
+// The embedded metadata exposes the repository, commit, pipeline, step and
+// system information of the current pipeline, e.g. `{{ .Repository.Slug }}`.
+type TemplateData struct {
+	plugin_base.Metadata
+	Vars map[string]string
+}
+
+// RenderMessage renders the message as Go template with the sprig functions,
+// the pipeline metadata and the configured template variables.
+func (p *Plugin) RenderMessage(message string) (string, error) {
+	tmpl, err := template.New("message").
+		Funcs(funcMap()).
+		Option("missingkey=error").
+		Parse(message)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse message template: %w", err)
+	}
+
+	data := TemplateData{
+		Metadata: p.Metadata,
+		Vars:     p.Settings.TemplateVars.Get(),
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render message template: %w", err)
+	}
+
+	return out.String(), nil
+}
+
+// funcMap returns the template functions without access to the environment, which holds
+// the credentials of the plugin.
+func funcMap() template.FuncMap {
+	funcs := plugin_template.LoadFuncMap()
+
+	delete(funcs, "env")
+	delete(funcs, "expandenv")
+
+	return funcs
+}
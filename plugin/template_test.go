@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	plugin_base "github.com/thegeeklab/wp-plugin-go/v4/plugin"
+)
+
+func TestPlugin_RenderMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		vars    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "plain message",
+			message: "test message",
+			want:    "test message",
+		},
+		{
+			name:    "metadata",
+			message: "{{ .Repository.Slug }}@{{ .Curr.SHA | trunc 7 }} #{{ .Curr.PullRequest }}",
+			want:    "octocat/hello-world@4b825dc #42",
+		},
+		{
+			name:    "template vars",
+			message: "{{ .Vars.env | upper }}",
+			vars:    `{"env": "staging"}`,
+			want:    "STAGING",
+		},
+		{
+			name:    "missing template var",
+			message: "{{ .Vars.env }}",
+			wantErr: true,
+		},
+		{
+			name:    "environment",
+			message: `{{ env "PLUGIN_API_KEY" }}`,
+			wantErr: true,
+		},
+		{
+			name:    "expand environment",
+			message: `{{ expandenv "$PLUGIN_API_KEY" }}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			message: "{{ .Repository.Slug",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(nil)
+			p.Metadata = plugin_base.Metadata{
+				Repository: plugin_base.Repository{Slug: "octocat/hello-world"},
+				Curr: plugin_base.Commit{
+					SHA:         "4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+					PullRequest: 42,
+				},
+			}
+
+			if tt.vars != "" {
+				assert.NoError(t, p.Settings.TemplateVars.Set(tt.vars))
+			}
+
+			got, err := p.RenderMessage(tt.message)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package report
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	percent = 100
+
+	// percentScale rounds percentages to one decimal place.
+	percentScale = 10
+)
+
+var ErrInvalidCoverage = errors.New("invalid coverage profile")
+
+// Coverage is the statement coverage parsed from a Go coverage profile.
+type Coverage struct {
+	Packages []CoveragePackage
+}
+
+// CoveragePackage holds the statement coverage of a package.
+type CoveragePackage struct {
+	Name       string
+	Statements int
+	Covered    int
+}
+
+type coverageBlock struct {
+	statements int
+	covered    bool
+}
+
+// ParseCoverage parses the Go coverage profile at the given path, as written by
+// `go test -coverprofile`. Blocks that are listed multiple times, e.g. in merged profiles,
+// are counted once and are covered if any of the entries is covered.
+func ParseCoverage(path string) (*Coverage, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read coverage profile %s: %w", path, err)
+	}
+	defer file.Close()
+
+	blocks := make(map[string]coverageBlock)
+	scanner := bufio.NewScanner(file)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "mode:") {
+			continue
+		}
+
+		key, block, err := parseCoverageLine(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		if current, ok := blocks[key]; ok {
+			block.covered = block.covered || current.covered
+		}
+
+		blocks[key] = block
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read coverage profile %s: %w", path, err)
+	}
+
+	return newCoverage(blocks), nil
+}
+
+// parseCoverageLine parses a profile line in the format `file:start,end statements count`
+// and returns the block position as key.
+func parseCoverageLine(text string) (string, coverageBlock, error) {
+	fields := strings.Fields(text)
+	if len(fields) != 3 || !strings.Contains(fields[0], ":") {
+		return "", coverageBlock{}, fmt.Errorf("%w: unexpected format %q", ErrInvalidCoverage, text)
+	}
+
+	statements, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return "", coverageBlock{}, fmt.Errorf("%w: %w", ErrInvalidCoverage, err)
+	}
+
+	count, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return "", coverageBlock{}, fmt.Errorf("%w: %w", ErrInvalidCoverage, err)
+	}
+
+	return fields[0], coverageBlock{statements: statements, covered: count > 0}, nil
+}
+
+func newCoverage(blocks map[string]coverageBlock) *Coverage {
+	packages := make(map[string]*CoveragePackage)
+
+	for key, block := range blocks {
+		file := key[:strings.LastIndex(key, ":")]
+		name := path.Dir(file)
+
+		pkg, ok := packages[name]
+		if !ok {
+			pkg = &CoveragePackage{Name: name}
+			packages[name] = pkg
+		}
+
+		pkg.Statements += block.statements
+		if block.covered {
+			pkg.Covered += block.statements
+		}
+	}
+
+	coverage := &Coverage{}
+	for _, pkg := range packages {
+		coverage.Packages = append(coverage.Packages, *pkg)
+	}
+
+	slices.SortFunc(coverage.Packages, func(a, b CoveragePackage) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return coverage
+}
+
+// Total returns the coverage of all packages.
+func (c *Coverage) Total() CoveragePackage {
+	total := CoveragePackage{Name: "Total"}
+
+	for _, pkg := range c.Packages {
+		total.Statements += pkg.Statements
+		total.Covered += pkg.Covered
+	}
+
+	return total
+}
+
+// Package returns the coverage of the package with the given name.
+func (c *Coverage) Package(name string) (CoveragePackage, bool) {
+	idx := slices.IndexFunc(c.Packages, func(pkg CoveragePackage) bool { return pkg.Name == name })
+	if idx < 0 {
+		return CoveragePackage{}, false
+	}
+
+	return c.Packages[idx], true
+}
+
+// Percent returns the statement coverage in percent, rounded to one decimal place.
+func (p CoveragePackage) Percent() float64 {
+	if p.Statements == 0 {
+		return 0
+	}
+
+	return math.Round(float64(p.Covered)/float64(p.Statements)*percent*percentScale) / percentScale
+}
+
+// Markdown renders the total coverage and a table with the coverage per package. If a baseline
+// is given, the delta to the baseline is added for the total and each package. Packages that
+// do not fit into the given size are omitted.
+func (c *Coverage) Markdown(baseline *Coverage, size int) string {
+	var b strings.Builder
+
+	total := c.Total()
+
+	b.WriteString("### :bar_chart: Coverage\n\n")
+	fmt.Fprintf(&b, "**Total: %.1f%%**", total.Percent())
+
+	if baseline != nil {
+		fmt.Fprintf(&b, " (%s)", coverageDelta(total, baseline.Total(), true))
+	}
+
+	b.WriteString("\n\n")
+
+	if baseline != nil {
+		b.WriteString("| Package | Coverage | Delta |\n| --- | ---: | ---: |\n")
+	} else {
+		b.WriteString("| Package | Coverage |\n| --- | ---: |\n")
+	}
+
+	rows := make([]string, 0, len(c.Packages))
+
+	for _, pkg := range c.Packages {
+		row := fmt.Sprintf("| %s | %.1f%% |", escapeCell(pkg.Name), pkg.Percent())
+
+		if baseline != nil {
+			base, ok := baseline.Package(pkg.Name)
+			row += fmt.Sprintf(" %s |", coverageDelta(pkg, base, ok))
+		}
+
+		rows = append(rows, row+"\n")
+	}
+
+	b.WriteString(fitBlocks(rows, size-b.Len(), "packages"))
+
+	return b.String()
+}
+
+// coverageDelta returns the difference of the coverage to the baseline with an up or down
+// indicator.
+func coverageDelta(current, baseline CoveragePackage, ok bool) string {
+	if !ok {
+		return ":new: new"
+	}
+
+	delta := math.Round((current.Percent()-baseline.Percent())*percentScale) / percentScale
+
+	switch {
+	case delta > 0:
+		return fmt.Sprintf(":arrow_up: +%.1f%%", delta)
+	case delta < 0:
+		return fmt.Sprintf(":arrow_down: %.1f%%", delta)
+	}
+
+	return "0.0%"
+}
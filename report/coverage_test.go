@@ -0,0 +1,71 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const coverageProfile = `mode: set
+example.com/app/a/a.go:3.10,5.2 2 1
+example.com/app/a/a.go:7.10,9.2 2 0
+example.com/app/a/a.go:7.10,9.2 2 1
+example.com/app/b/b.go:3.10,5.2 3 1
+example.com/app/b/b.go:7.10,9.2 1 0
+`
+
+const coverageBaseline = `mode: set
+example.com/app/a/a.go:3.10,5.2 2 1
+example.com/app/a/a.go:7.10,9.2 2 0
+example.com/app/b/b.go:3.10,5.2 3 1
+example.com/app/b/b.go:7.10,9.2 1 0
+`
+
+func TestParseCoverage(t *testing.T) {
+	got, err := ParseCoverage(writeFile(t, "coverage.out", coverageProfile))
+	assert.NoError(t, err)
+	assert.Equal(t, []CoveragePackage{
+		{Name: "example.com/app/a", Statements: 4, Covered: 4},
+		{Name: "example.com/app/b", Statements: 4, Covered: 3},
+	}, got.Packages)
+	assert.InDelta(t, 87.5, got.Total().Percent(), 0)
+
+	_, err = ParseCoverage(writeFile(t, "invalid.out", "mode: set\nexample.com/app/a/a.go:3.10,5.2 x 1\n"))
+	assert.ErrorIs(t, err, ErrInvalidCoverage)
+}
+
+func TestCoverage_Markdown(t *testing.T) {
+	current, err := ParseCoverage(writeFile(t, "coverage.out", coverageProfile+"example.com/app/c/c.go:3.10,5.2 1 1\n"))
+	assert.NoError(t, err)
+
+	baseline, err := ParseCoverage(writeFile(t, "baseline.out", coverageBaseline))
+	assert.NoError(t, err)
+
+	assert.Equal(t, `### :bar_chart: Coverage
+
+**Total: 88.9%**
+
+| Package | Coverage |
+| --- | ---: |
+| example.com/app/a | 100.0% |
+| example.com/app/b | 75.0% |
+| example.com/app/c | 100.0% |
+`, current.Markdown(nil, 10000))
+
+	assert.Equal(t, `### :bar_chart: Coverage
+
+**Total: 88.9%** (:arrow_up: +26.4%)
+
+| Package | Coverage | Delta |
+| --- | ---: | ---: |
+| example.com/app/a | 100.0% | :arrow_up: +50.0% |
+| example.com/app/b | 75.0% | 0.0% |
+| example.com/app/c | 100.0% | :new: new |
+`, current.Markdown(baseline, 10000))
+
+	got := current.Markdown(nil, 150)
+	assert.Contains(t, got, "| example.com/app/a | 100.0% |\n")
+	assert.NotContains(t, got, "example.com/app/b")
+	assert.Contains(t, got, "_2 more packages not shown._")
+	assert.LessOrEqual(t, len(got), 150)
+}
@@ -0,0 +1,217 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// maxTraceLength is the maximum length of a single failure output in the report.
+const maxTraceLength = 4096
+
+// JUnit is a test report parsed from one or more JUnit XML files.
+type JUnit struct {
+	Suites []JUnitSuite
+}
+
+// JUnitSuite holds the results of a test suite.
+type JUnitSuite struct {
+	Name     string
+	Passed   int
+	Failed   int
+	Skipped  int
+	Duration time.Duration
+	Failures []JUnitFailure
+}
+
+// JUnitFailure describes a failed test case or a test case that raised an error.
+type JUnitFailure struct {
+	Name    string
+	Message string
+	Output  string
+}
+
+type junitSuite struct {
+	Name   string       `xml:"name,attr"`
+	Time   float64      `xml:"time,attr"`
+	Cases  []junitCase  `xml:"testcase"`
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+type junitCase struct {
+	Name      string       `xml:"name,attr"`
+	Classname string       `xml:"classname,attr"`
+	Time      float64      `xml:"time,attr"`
+	Failure   *junitResult `xml:"failure"`
+	Error     *junitResult `xml:"error"`
+	Skipped   *junitResult `xml:"skipped"`
+	SystemOut string       `xml:"system-out"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ParseJUnit parses the given JUnit XML files. Both a single testsuite and a testsuites
+// element are accepted as root element, nested test suites are flattened.
+func ParseJUnit(paths []string) (*JUnit, error) {
+	report := &JUnit{}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read junit report %s: %w", path, err)
+		}
+
+		var root junitSuite
+		if err := xml.Unmarshal(data, &root); err != nil {
+			return nil, fmt.Errorf("failed to parse junit report %s: %w", path, err)
+		}
+
+		report.add(root)
+	}
+
+	return report, nil
+}
+
+// add adds the suite and all nested suites that contain test cases to the report.
+func (r *JUnit) add(suite junitSuite) {
+	for _, nested := range suite.Suites {
+		r.add(nested)
+	}
+
+	if len(suite.Cases) == 0 {
+		return
+	}
+
+	result := JUnitSuite{Name: suite.Name}
+
+	var total float64
+
+	for _, tc := range suite.Cases {
+		total += tc.Time
+
+		switch {
+		case tc.Failure != nil:
+			result.Failed++
+			result.Failures = append(result.Failures, newJUnitFailure(tc, tc.Failure))
+		case tc.Error != nil:
+			result.Failed++
+			result.Failures = append(result.Failures, newJUnitFailure(tc, tc.Error))
+		case tc.Skipped != nil:
+			result.Skipped++
+		default:
+			result.Passed++
+		}
+	}
+
+	if suite.Time > 0 {
+		total = suite.Time
+	}
+
+	result.Duration = seconds(total)
+
+	r.Suites = append(r.Suites, result)
+}
+
+func newJUnitFailure(tc junitCase, result *junitResult) JUnitFailure {
+	name := tc.Name
+	if tc.Classname != "" {
+		name = tc.Classname + "/" + tc.Name
+	}
+
+	output := strings.TrimSpace(result.Text)
+	if output == "" {
+		output = strings.TrimSpace(tc.SystemOut)
+	}
+
+	message := result.Message
+	if message == "" {
+		message = result.Type
+	}
+
+	return JUnitFailure{Name: name, Message: message, Output: output}
+}
+
+// Total returns the sum of all suites.
+func (r *JUnit) Total() JUnitSuite {
+	total := JUnitSuite{Name: "Total"}
+
+	for _, suite := range r.Suites {
+		total.Passed += suite.Passed
+		total.Failed += suite.Failed
+		total.Skipped += suite.Skipped
+		total.Duration += suite.Duration
+		total.Failures = append(total.Failures, suite.Failures...)
+	}
+
+	return total
+}
+
+// Markdown renders the report as summary table followed by collapsible failure details.
+// Failure details that do not fit into the given size are omitted and replaced by a note
+// with the number of omitted failures, the summary is always kept.
+func (r *JUnit) Markdown(size int) string {
+	total := r.Total()
+
+	var b strings.Builder
+
+	status := ":white_check_mark:"
+	if total.Failed > 0 {
+		status = ":x:"
+	}
+
+	fmt.Fprintf(&b, "### %s Test Results\n\n", status)
+	b.WriteString("| Suite | Passed | Failed | Skipped | Duration |\n")
+	b.WriteString("| --- | ---: | ---: | ---: | ---: |\n")
+
+	for _, suite := range r.Suites {
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %s |\n",
+			escapeCell(suite.Name), suite.Passed, suite.Failed, suite.Skipped, suite.Duration)
+	}
+
+	fmt.Fprintf(&b, "| **Total** | **%d** | **%d** | **%d** | **%s** |\n",
+		total.Passed, total.Failed, total.Skipped, total.Duration)
+
+	if len(total.Failures) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("\n#### Failures\n")
+
+	blocks := make([]string, 0, len(total.Failures))
+	for _, failure := range total.Failures {
+		blocks = append(blocks, failure.markdown())
+	}
+
+	b.WriteString(fitBlocks(blocks, size-b.Len(), "failures"))
+
+	return b.String()
+}
+
+func (f JUnitFailure) markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "\n<details>\n<summary>:x: %s</summary>\n\n", escapeHTML(f.Name))
+
+	if f.Message != "" {
+		fmt.Fprintf(&b, "%s\n\n", quote(f.Message))
+	}
+
+	if f.Output != "" {
+		fmt.Fprintf(&b, "```\n%s\n```\n\n", truncate(f.Output, maxTraceLength))
+	}
+
+	b.WriteString("</details>\n")
+
+	return b.String()
+}
+
+// seconds converts a duration in seconds to a duration rounded to milliseconds.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second)).Round(time.Millisecond)
+}
@@ -0,0 +1,137 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const junitSuitesXML = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="pkg/a" tests="3" time="1.5">
+    <testcase classname="pkg/a" name="TestPass" time="0.5"/>
+    <testcase classname="pkg/a" name="TestFail" time="1">
+      <failure message="expected 1, got 2" type="assertion">a_test.go:12: expected 1, got 2</failure>
+    </testcase>
+    <testcase classname="pkg/a" name="TestSkip">
+      <skipped message="not supported"/>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+const junitSuiteXML = `<testsuite name="pkg/b">
+  <testcase classname="pkg/b" name="TestPass" time="0.25"/>
+  <testcase classname="pkg/b" name="TestError" time="0.25">
+    <error message="panic"/>
+    <system-out>goroutine 1 [running]</system-out>
+  </testcase>
+</testsuite>
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestParseJUnit(t *testing.T) {
+	got, err := ParseJUnit([]string{
+		writeFile(t, "a.xml", junitSuitesXML),
+		writeFile(t, "b.xml", junitSuiteXML),
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []JUnitSuite{
+		{
+			Name:     "pkg/a",
+			Passed:   1,
+			Failed:   1,
+			Skipped:  1,
+			Duration: 1500 * time.Millisecond,
+			Failures: []JUnitFailure{
+				{Name: "pkg/a/TestFail", Message: "expected 1, got 2", Output: "a_test.go:12: expected 1, got 2"},
+			},
+		},
+		{
+			Name:     "pkg/b",
+			Passed:   1,
+			Failed:   1,
+			Duration: 500 * time.Millisecond,
+			Failures: []JUnitFailure{
+				{Name: "pkg/b/TestError", Message: "panic", Output: "goroutine 1 [running]"},
+			},
+		},
+	}, got.Suites)
+
+	_, err = ParseJUnit([]string{writeFile(t, "invalid.xml", "<testsuite>")})
+	assert.Error(t, err)
+}
+
+func TestJUnit_Markdown(t *testing.T) {
+	report := &JUnit{
+		Suites: []JUnitSuite{
+			{Name: "pkg|a", Passed: 2, Skipped: 1, Duration: time.Second},
+			{
+				Name:     "pkg/b",
+				Passed:   1,
+				Failed:   2,
+				Duration: 250 * time.Millisecond,
+				Failures: []JUnitFailure{
+					{Name: "pkg/b/TestOne", Message: "first failure", Output: "trace one"},
+					{Name: "pkg/b/TestTwo", Message: "second failure", Output: "trace two"},
+				},
+			},
+		},
+	}
+
+	got := report.Markdown(10000)
+	assert.Equal(t, `### :x: Test Results
+
+| Suite | Passed | Failed | Skipped | Duration |
+| --- | ---: | ---: | ---: | ---: |
+| pkg\|a | 2 | 0 | 1 | 1s |
+| pkg/b | 1 | 2 | 0 | 250ms |
+| **Total** | **3** | **2** | **1** | **1.25s** |
+
+#### Failures
+
+<details>
+<summary>:x: pkg/b/TestOne</summary>
+
+> first failure
+
+`+"```"+`
+trace one
+`+"```"+`
+
+</details>
+
+<details>
+<summary>:x: pkg/b/TestTwo</summary>
+
+> second failure
+
+`+"```"+`
+trace two
+`+"```"+`
+
+</details>
+`, got)
+
+	summary, _, _ := strings.Cut(got, "\n<details>")
+
+	limited := report.Markdown(len(summary) + 150)
+	assert.True(t, strings.HasPrefix(limited, summary))
+	assert.Contains(t, limited, "TestOne")
+	assert.NotContains(t, limited, "TestTwo")
+	assert.True(t, strings.HasSuffix(limited, "\n_1 more failures not shown._\n"))
+	assert.LessOrEqual(t, len(limited), len(summary)+150)
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// truncateMark marks text that is cut by truncate.
+const truncateMark = "\n..."
+
+// fitBlocks joins as many blocks as fit into the given size. If blocks are omitted, a note
+// with the number of omitted items is added, which is reserved in the size as well.
+func fitBlocks(blocks []string, size int, items string) string {
+	var b strings.Builder
+
+	reserve := len(omittedNote(len(blocks), items))
+
+	for i, block := range blocks {
+		last := i == len(blocks)-1
+
+		if b.Len()+len(block) > size || (!last && b.Len()+len(block)+reserve > size) {
+			b.WriteString(omittedNote(len(blocks)-i, items))
+
+			break
+		}
+
+		b.WriteString(block)
+	}
+
+	return b.String()
+}
+
+func omittedNote(count int, items string) string {
+	return fmt.Sprintf("\n_%d more %s not shown._\n", count, items)
+}
+
+// truncate cuts the text to the given size at a rune boundary and marks the cut. The mark is
+// included in the size.
+func truncate(text string, size int) string {
+	if len(text) <= size {
+		return text
+	}
+
+	size = max(size-len(truncateMark), 0)
+
+	for size > 0 && !utf8.RuneStart(text[size]) {
+		size--
+	}
+
+	return text[:size] + truncateMark
+}
+
+// backticks returns a run of at least n backticks that is longer than any run of backticks
+// in the text, so it can delimit the text as code.
+func backticks(text string, n int) string {
+	run := 0
+
+	for _, r := range text {
+		if r != '`' {
+			run = 0
+
+			continue
+		}
+
+		run++
+		n = max(n, run+1)
+	}
+
+	return strings.Repeat("`", n)
+}
+
+// codeSpan renders the text as inline code.
+func codeSpan(text string) string {
+	delim := backticks(text, 1)
+	if strings.Contains(text, "`") {
+		return delim + " " + text + " " + delim
+	}
+
+	return delim + text + delim
+}
+
+// escapeCell escapes the text for use in a markdown table cell.
+func escapeCell(text string) string {
+	return strings.ReplaceAll(escapeHTML(text), "|", `\|`)
+}
+
+// escapeHTML escapes the text for use in HTML elements such as summary.
+func escapeHTML(text string) string {
+	return html.EscapeString(strings.Join(strings.Fields(text), " "))
+}
+
+// quote renders the text as markdown block quote.
+func quote(text string) string {
+	return "> " + strings.ReplaceAll(strings.TrimSpace(text), "\n", "\n> ")
+}
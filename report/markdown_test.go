@@ -0,0 +1,28 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		size int
+		want string
+	}{
+		{name: "fits", text: "test message", size: 12, want: "test message"},
+		{name: "cut", text: "test message", size: 8, want: "test\n..."},
+		{name: "rune boundary", text: "test ümlaut", size: 10, want: "test \n..."},
+		{name: "no space", text: "test message", size: 2, want: "\n..."},
+		{name: "negative size", text: "test message", size: -10, want: "\n..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, truncate(tt.text, tt.size))
+		})
+	}
+}
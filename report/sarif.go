@@ -0,0 +1,324 @@
+package report
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// maxFindings is the maximum number of findings listed in the report.
+const maxFindings = 50
+
+// Severity levels of SARIF findings.
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+	LevelNone    = "none"
+)
+
+// SARIF holds the findings parsed from one or more SARIF files.
+type SARIF struct {
+	Findings []Finding
+}
+
+// Finding is a single result of a static analysis tool.
+type Finding struct {
+	Tool        string
+	Rule        string
+	Description string
+	Level       string
+	Message     string
+	Path        string
+	Line        int
+}
+
+// LinkFunc returns the URL of the given line of a file in the repository, or an empty string
+// if the file can not be linked.
+type LinkFunc func(path string, line int) string
+
+type sarifLog struct {
+	Runs []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name  string      `json:"name"`
+			Rules []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	Configuration    struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex *int            `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine int `json:"startLine"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+// ParseSARIF parses the given SARIF files. Absolute file locations are made relative to the
+// given root directory, e.g. the repository checkout.
+func ParseSARIF(paths []string, root string) (*SARIF, error) {
+	report := &SARIF{}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sarif report %s: %w", path, err)
+		}
+
+		var log sarifLog
+		if err := json.Unmarshal(data, &log); err != nil {
+			return nil, fmt.Errorf("failed to parse sarif report %s: %w", path, err)
+		}
+
+		for _, run := range log.Runs {
+			for _, result := range run.Results {
+				report.Findings = append(report.Findings, newFinding(run, result, root))
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func newFinding(run sarifRun, result sarifResult, root string) Finding {
+	rules := run.Tool.Driver.Rules
+
+	var rule sarifRule
+
+	if result.RuleIndex != nil && *result.RuleIndex >= 0 && *result.RuleIndex < len(rules) {
+		rule = rules[*result.RuleIndex]
+	} else if idx := slices.IndexFunc(rules, func(r sarifRule) bool { return r.ID == result.RuleID }); idx >= 0 {
+		rule = rules[idx]
+	}
+
+	finding := Finding{
+		Tool:        run.Tool.Driver.Name,
+		Rule:        cmp.Or(result.RuleID, rule.ID),
+		Description: cmp.Or(rule.ShortDescription.Text, rule.Name),
+		Level:       cmp.Or(result.Level, rule.Configuration.Level, LevelWarning),
+		Message:     result.Message.Text,
+	}
+
+	if len(result.Locations) > 0 {
+		location := result.Locations[0].PhysicalLocation
+		finding.Path = relativePath(location.ArtifactLocation.URI, root)
+		finding.Line = location.Region.StartLine
+	}
+
+	return finding
+}
+
+// relativePath converts the artifact URI to a slash separated path relative to the root.
+func relativePath(uri, root string) string {
+	path := strings.TrimPrefix(uri, "file://")
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+
+	if filepath.IsAbs(path) && root != "" {
+		if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+
+	return filepath.ToSlash(path)
+}
+
+type findingGroup struct {
+	Finding
+	findings []Finding
+}
+
+// groups returns the findings grouped by level and rule, ordered by severity and rule.
+func (r *SARIF) groups() []*findingGroup {
+	var groups []*findingGroup
+
+	for _, finding := range r.Findings {
+		idx := slices.IndexFunc(groups, func(g *findingGroup) bool {
+			return g.Level == finding.Level && g.Rule == finding.Rule && g.Tool == finding.Tool
+		})
+		if idx < 0 {
+			groups = append(groups, &findingGroup{Finding: finding})
+			idx = len(groups) - 1
+		}
+
+		groups[idx].findings = append(groups[idx].findings, finding)
+	}
+
+	slices.SortStableFunc(groups, func(a, b *findingGroup) int {
+		return cmp.Or(
+			cmp.Compare(levelRank(a.Level), levelRank(b.Level)),
+			strings.Compare(a.Tool, b.Tool),
+			strings.Compare(a.Rule, b.Rule),
+		)
+	})
+
+	return groups
+}
+
+// Markdown renders a summary table of the findings per rule and severity, followed by the
+// findings of each rule in collapsible blocks that fit into the given size.
+func (r *SARIF) Markdown(link LinkFunc, size int) string {
+	var b strings.Builder
+
+	if len(r.Findings) == 0 {
+		return "### :white_check_mark: Findings\n\nNo findings.\n"
+	}
+
+	groups := r.groups()
+
+	fmt.Fprintf(&b, "### %s Findings\n\n", levelIcon(groups[0].Level))
+	b.WriteString("| Severity | Rule | Tool | Findings |\n")
+	b.WriteString("| --- | --- | --- | ---: |\n")
+
+	rows := make([]string, 0, len(groups))
+
+	for _, group := range groups {
+		rule := "`" + escapeCell(group.Rule) + "`"
+		if group.Description != "" {
+			rule += " " + escapeCell(group.Description)
+		}
+
+		rows = append(rows, fmt.Sprintf("| %s %s | %s | %s | %d |\n",
+			levelIcon(group.Level), group.Level, rule, escapeCell(group.Tool), len(group.findings)))
+	}
+
+	// The note of omitted findings is always reserved, since it is added whenever findings
+	// are not listed.
+	reserve := len(omittedNote(len(r.Findings), "findings"))
+
+	b.WriteString(fitBlocks(rows, size-b.Len()-reserve, "rules"))
+
+	blocks := make([]string, 0, len(groups))
+	counts := make([]int, 0, len(groups))
+	listed := 0
+
+	for _, group := range groups {
+		if listed >= maxFindings {
+			break
+		}
+
+		findings := group.findings[:min(len(group.findings), maxFindings-listed)]
+		listed += len(findings)
+
+		blocks = append(blocks, group.markdown(findings, link))
+		counts = append(counts, len(findings))
+	}
+
+	// Omitted rules are already noted below the table, so the findings of rules that do not
+	// fit are only counted in the note of omitted findings.
+	shown := 0
+
+	for i, block := range blocks {
+		if b.Len()+len(block) > size-reserve {
+			break
+		}
+
+		b.WriteString(block)
+
+		shown += counts[i]
+	}
+
+	if omitted := len(r.Findings) - shown; omitted > 0 {
+		b.WriteString(omittedNote(omitted, "findings"))
+	}
+
+	return b.String()
+}
+
+func (g *findingGroup) markdown(findings []Finding, link LinkFunc) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "\n<details>\n<summary>%s %s: %s (%d)</summary>\n\n",
+		levelIcon(g.Level), g.Level, escapeHTML(g.Rule), len(g.findings))
+
+	for _, finding := range findings {
+		location := finding.location()
+		if target := finding.url(link); target != "" {
+			location = fmt.Sprintf("[%s](%s)", location, target)
+		}
+
+		fmt.Fprintf(&b, "- %s: %s\n", location, escapeHTML(finding.Message))
+	}
+
+	b.WriteString("\n</details>\n")
+
+	return b.String()
+}
+
+// location returns the file and line of the finding.
+func (f Finding) location() string {
+	if f.Path == "" {
+		return "`unknown location`"
+	}
+
+	if f.Line > 0 {
+		return fmt.Sprintf("`%s:%d`", f.Path, f.Line)
+	}
+
+	return "`" + f.Path + "`"
+}
+
+func (f Finding) url(link LinkFunc) string {
+	if link == nil || f.Path == "" {
+		return ""
+	}
+
+	return link(f.Path, f.Line)
+}
+
+// levelRank returns the sort order of the level, from the most to the least severe level.
+func levelRank(level string) int {
+	levels := []string{LevelError, LevelWarning, LevelNote, LevelNone}
+
+	if rank := slices.Index(levels, level); rank >= 0 {
+		return rank
+	}
+
+	return len(levels)
+}
+
+func levelIcon(level string) string {
+	switch level {
+	case LevelError:
+		return ":x:"
+	case LevelWarning:
+		return ":warning:"
+	}
+
+	return ":information_source:"
+}
@@ -0,0 +1,164 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sarifLint = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "golangci-lint", "rules": [
+      {"id": "errcheck", "shortDescription": {"text": "Unchecked error"}},
+      {"id": "gosec", "defaultConfiguration": {"level": "error"}}
+    ]}},
+    "results": [
+      {
+        "ruleId": "errcheck",
+        "level": "warning",
+        "message": {"text": "Error return value is not checked"},
+        "locations": [{"physicalLocation": {
+          "artifactLocation": {"uri": "file:///src/app/plugin/impl.go"},
+          "region": {"startLine": 42}
+        }}]
+      },
+      {
+        "ruleIndex": 1,
+        "ruleId": "gosec",
+        "message": {"text": "Potential file inclusion"},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}}}]
+      },
+      {
+        "ruleId": "unknown",
+        "message": {"text": "No location"}
+      }
+    ]
+  }]
+}
+`
+
+func TestParseSARIF(t *testing.T) {
+	got, err := ParseSARIF([]string{writeFile(t, "lint.sarif", sarifLint)}, "/src/app")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Finding{
+		{
+			Tool:        "golangci-lint",
+			Rule:        "errcheck",
+			Description: "Unchecked error",
+			Level:       LevelWarning,
+			Message:     "Error return value is not checked",
+			Path:        "plugin/impl.go",
+			Line:        42,
+		},
+		{
+			Tool:    "golangci-lint",
+			Rule:    "gosec",
+			Level:   LevelError,
+			Message: "Potential file inclusion",
+			Path:    "main.go",
+		},
+		{
+			Tool:    "golangci-lint",
+			Rule:    "unknown",
+			Level:   LevelWarning,
+			Message: "No location",
+		},
+	}, got.Findings)
+
+	_, err = ParseSARIF([]string{writeFile(t, "invalid.sarif", "{")}, "")
+	assert.Error(t, err)
+}
+
+func TestRelativePath(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		root string
+		want string
+	}{
+		{name: "relative path", uri: "plugin/impl.go", root: "/src/app", want: "plugin/impl.go"},
+		{name: "file uri", uri: "file:///src/app/plugin/impl.go", root: "/src/app", want: "plugin/impl.go"},
+		{name: "escaped uri", uri: "file:///src/app/my%20file.go", root: "/src/app", want: "my file.go"},
+		{name: "outside root", uri: "file:///usr/lib/go/os.go", root: "/src/app", want: "/usr/lib/go/os.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, relativePath(tt.uri, tt.root))
+		})
+	}
+}
+
+func TestSARIF_Markdown(t *testing.T) {
+	report := &SARIF{
+		Findings: []Finding{
+			{Tool: "lint", Rule: "style", Level: LevelNote, Message: "Use camel case", Path: "a.go", Line: 3},
+			{Tool: "lint", Rule: "errcheck", Level: LevelWarning, Message: "Unchecked <error>", Path: "a.go", Line: 7},
+			{Tool: "sec", Rule: "G304", Description: "File inclusion", Level: LevelError, Message: "Tainted path"},
+			{Tool: "lint", Rule: "errcheck", Level: LevelWarning, Message: "Unchecked error", Path: "b.go"},
+		},
+	}
+
+	link := func(path string, line int) string {
+		return fmt.Sprintf("https://example.com/%s#L%d", path, line)
+	}
+
+	got := report.Markdown(link, 10000)
+
+	assert.Equal(t, "### :x: Findings\n\n"+
+		"| Severity | Rule | Tool | Findings |\n"+
+		"| --- | --- | --- | ---: |\n"+
+		"| :x: error | `G304` File inclusion | sec | 1 |\n"+
+		"| :warning: warning | `errcheck` | lint | 2 |\n"+
+		"| :information_source: note | `style` | lint | 1 |\n"+
+		"\n<details>\n<summary>:x: error: G304 (1)</summary>\n\n"+
+		"- `unknown location`: Tainted path\n"+
+		"\n</details>\n"+
+		"\n<details>\n<summary>:warning: warning: errcheck (2)</summary>\n\n"+
+		"- [`a.go:7`](https://example.com/a.go#L7): Unchecked &lt;error&gt;\n"+
+		"- [`b.go`](https://example.com/b.go#L0): Unchecked error\n"+
+		"\n</details>\n"+
+		"\n<details>\n<summary>:information_source: note: style (1)</summary>\n\n"+
+		"- [`a.go:3`](https://example.com/a.go#L3): Use camel case\n"+
+		"\n</details>\n", got)
+
+	assert.Equal(t, "### :white_check_mark: Findings\n\nNo findings.\n", (&SARIF{}).Markdown(nil, 10000))
+}
+
+func TestSARIF_Markdown_Limit(t *testing.T) {
+	report := &SARIF{}
+	for i := range maxFindings + 5 {
+		report.Findings = append(report.Findings, Finding{
+			Tool: "lint", Rule: "errcheck", Level: LevelWarning, Message: "Unchecked error", Path: "a.go", Line: i + 1,
+		})
+	}
+
+	got := report.Markdown(nil, 100000)
+	assert.Equal(t, maxFindings, strings.Count(got, "- `a.go:"))
+	assert.True(t, strings.HasSuffix(got, "\n_5 more findings not shown._\n"))
+
+	got = report.Markdown(nil, 200)
+	assert.Contains(t, got, "| :warning: warning | `errcheck` | lint | 55 |\n")
+	assert.NotContains(t, got, "<details>")
+	assert.NotContains(t, got, "more rules not shown")
+	assert.True(t, strings.HasSuffix(got, "\n_55 more findings not shown._\n"))
+
+	// Rules that do not fit into the table are omitted as well.
+	report = &SARIF{}
+	for i := range 100 {
+		report.Findings = append(report.Findings, Finding{
+			Tool: "lint", Rule: fmt.Sprintf("rule%03d", i), Level: LevelWarning, Message: "Finding", Path: "a.go",
+		})
+	}
+
+	got = report.Markdown(nil, 1000)
+	assert.LessOrEqual(t, len(got), 1000)
+	assert.Contains(t, got, "| :warning: warning | `rule000` | lint | 1 |\n")
+	assert.NotContains(t, got, "`rule099`")
+	assert.Equal(t, 1, strings.Count(got, "more rules not shown"))
+	assert.True(t, strings.HasSuffix(got, "\n_100 more findings not shown._\n"))
+}
@@ -0,0 +1,245 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Actions of Terraform resource changes. A replacement destroys and re-creates the resource.
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionReplace = "replace"
+)
+
+// minPlanOutput is the minimum space required to render the plan output, smaller outputs
+// are omitted instead of being cut to a few lines.
+const minPlanOutput = 256
+
+// planNotShown replaces the plan output if it does not fit.
+const planNotShown = "\n_Plan output not shown._\n"
+
+// ansiEscape matches the color codes in plan outputs that were not written with -no-color.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// Terraform is a plan parsed from the JSON output of `terraform show -json` or
+// `tofu show -json`.
+type Terraform struct {
+	Changes []TerraformChange
+}
+
+// TerraformChange is a planned change of a managed resource.
+type TerraformChange struct {
+	Address string
+	Type    string
+	Action  string
+}
+
+// TerraformType holds the number of planned changes of a resource type.
+type TerraformType struct {
+	Name    string
+	Add     int
+	Change  int
+	Destroy int
+}
+
+type terraformPlan struct {
+	ResourceChanges []struct {
+		Address string `json:"address"`
+		Mode    string `json:"mode"`
+		Type    string `json:"type"`
+		Change  struct {
+			Actions []string `json:"actions"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+}
+
+// ParseTerraform parses the JSON plan at the given path. Data sources and resources without
+// changes are skipped.
+func ParseTerraform(path string) (*Terraform, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read terraform plan %s: %w", path, err)
+	}
+
+	var plan terraformPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse terraform plan %s: %w", path, err)
+	}
+
+	report := &Terraform{}
+
+	for _, rc := range plan.ResourceChanges {
+		if rc.Mode == "data" {
+			continue
+		}
+
+		action := terraformAction(rc.Change.Actions)
+		if action == "" {
+			continue
+		}
+
+		report.Changes = append(report.Changes, TerraformChange{Address: rc.Address, Type: rc.Type, Action: action})
+	}
+
+	return report, nil
+}
+
+// terraformAction maps the actions of a resource change to a single action, or an empty
+// string if the resource is not changed.
+func terraformAction(actions []string) string {
+	switch {
+	case slices.Contains(actions, ActionDelete) && slices.Contains(actions, ActionCreate):
+		return ActionReplace
+	case slices.Contains(actions, ActionCreate):
+		return ActionCreate
+	case slices.Contains(actions, ActionUpdate):
+		return ActionUpdate
+	case slices.Contains(actions, ActionDelete):
+		return ActionDelete
+	}
+
+	return ""
+}
+
+// Types returns the number of changes per resource type, ordered by type. As in the plan
+// summary of Terraform, a replacement counts as one add and one destroy.
+func (r *Terraform) Types() []TerraformType {
+	var types []TerraformType
+
+	for _, change := range r.Changes {
+		idx := slices.IndexFunc(types, func(t TerraformType) bool { return t.Name == change.Type })
+		if idx < 0 {
+			types = append(types, TerraformType{Name: change.Type})
+			idx = len(types) - 1
+		}
+
+		types[idx].add(change.Action)
+	}
+
+	slices.SortFunc(types, func(a, b TerraformType) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return types
+}
+
+// Total returns the number of changes of all resource types.
+func (r *Terraform) Total() TerraformType {
+	total := TerraformType{Name: "Total"}
+
+	for _, change := range r.Changes {
+		total.add(change.Action)
+	}
+
+	return total
+}
+
+func (t *TerraformType) add(action string) {
+	switch action {
+	case ActionCreate:
+		t.Add++
+	case ActionUpdate:
+		t.Change++
+	case ActionDelete:
+		t.Destroy++
+	case ActionReplace:
+		t.Add++
+		t.Destroy++
+	}
+}
+
+// Markdown renders the plan summary with the destroyed resources, followed by the plan output
+// in a collapsible block that is truncated to the given size.
+func (r *Terraform) Markdown(output string, size int) string {
+	var b strings.Builder
+
+	total := r.Total()
+
+	if len(r.Changes) == 0 {
+		return "### :white_check_mark: Plan\n\nNo changes. Your infrastructure matches the configuration.\n"
+	}
+
+	status := ":memo:"
+	if total.Destroy > 0 {
+		status = ":warning:"
+	}
+
+	fmt.Fprintf(&b, "### %s Plan\n\n", status)
+	fmt.Fprintf(&b, "**Plan: %d to add, %d to change, %d to destroy.**\n\n", total.Add, total.Change, total.Destroy)
+	b.WriteString("| Resource Type | Add | Change | Destroy |\n")
+	b.WriteString("| --- | ---: | ---: | ---: |\n")
+
+	for _, t := range r.Types() {
+		destroy := strconv.Itoa(t.Destroy)
+		if t.Destroy > 0 {
+			destroy = "**" + destroy + "**"
+		}
+
+		fmt.Fprintf(&b, "| %s | %d | %d | %s |\n", escapeCell(t.Name), t.Add, t.Change, destroy)
+	}
+
+	if total.Destroy > 0 {
+		b.WriteString("\n:warning: **The following resources will be destroyed:**\n\n")
+
+		var items []string
+
+		for _, change := range r.Changes {
+			switch change.Action {
+			case ActionDelete:
+				items = append(items, fmt.Sprintf("- %s\n", codeSpan(change.Address)))
+			case ActionReplace:
+				items = append(items, fmt.Sprintf("- %s (replace)\n", codeSpan(change.Address)))
+			}
+		}
+
+		b.WriteString(fitBlocks(items, size-b.Len()-len(planNotShown), "resources"))
+	}
+
+	b.WriteString(r.details(output, size-b.Len()))
+
+	return b.String()
+}
+
+// details renders the plan output in a collapsible block that fits into the given size.
+// The code fence is longer than any run of backticks in the output to keep the block intact.
+func (r *Terraform) details(output string, size int) string {
+	lang := ""
+	if output = strings.TrimSpace(ansiEscape.ReplaceAllString(output, "")); output == "" {
+		lang, output = "diff", r.changeList()
+	}
+
+	fence := backticks(output, 3)
+	header := "\n<details>\n<summary>Show plan</summary>\n\n" + fence + lang + "\n"
+	footer := "\n" + fence + "\n\n</details>\n"
+
+	size -= len(header) + len(footer)
+	if size < minPlanOutput {
+		return planNotShown
+	}
+
+	return header + truncate(output, size) + footer
+}
+
+// changeList lists the planned changes with the symbols used by Terraform for each action.
+func (r *Terraform) changeList() string {
+	symbols := map[string]string{
+		ActionCreate:  "+",
+		ActionUpdate:  "~",
+		ActionDelete:  "-",
+		ActionReplace: "-/+",
+	}
+
+	lines := make([]string, 0, len(r.Changes))
+	for _, change := range r.Changes {
+		lines = append(lines, fmt.Sprintf("%s %s", symbols[change.Action], change.Address))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,137 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const terraformPlanJSON = `{
+  "format_version": "1.2",
+  "terraform_version": "1.9.0",
+  "resource_changes": [
+    {"address": "aws_instance.web", "type": "aws_instance", "change": {"actions": ["create"]}},
+    {"address": "aws_instance.db", "type": "aws_instance", "change": {"actions": ["delete", "create"]}},
+    {"address": "aws_s3_bucket.logs", "type": "aws_s3_bucket", "change": {"actions": ["update"]}},
+    {"address": "aws_s3_bucket.old", "type": "aws_s3_bucket", "change": {"actions": ["delete"]}},
+    {"address": "aws_iam_role.ci", "type": "aws_iam_role", "change": {"actions": ["no-op"]}},
+    {"address": "data.aws_ami.ubuntu", "mode": "data", "type": "aws_ami", "change": {"actions": ["read"]}}
+  ]
+}
+`
+
+func TestParseTerraform(t *testing.T) {
+	got, err := ParseTerraform(writeFile(t, "plan.json", terraformPlanJSON))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []TerraformChange{
+		{Address: "aws_instance.web", Type: "aws_instance", Action: ActionCreate},
+		{Address: "aws_instance.db", Type: "aws_instance", Action: ActionReplace},
+		{Address: "aws_s3_bucket.logs", Type: "aws_s3_bucket", Action: ActionUpdate},
+		{Address: "aws_s3_bucket.old", Type: "aws_s3_bucket", Action: ActionDelete},
+	}, got.Changes)
+
+	assert.Equal(t, []TerraformType{
+		{Name: "aws_instance", Add: 2, Destroy: 1},
+		{Name: "aws_s3_bucket", Change: 1, Destroy: 1},
+	}, got.Types())
+	assert.Equal(t, TerraformType{Name: "Total", Add: 2, Change: 1, Destroy: 2}, got.Total())
+
+	_, err = ParseTerraform(writeFile(t, "invalid.json", "{"))
+	assert.Error(t, err)
+}
+
+func TestTerraform_Markdown(t *testing.T) {
+	plan, err := ParseTerraform(writeFile(t, "plan.json", terraformPlanJSON))
+	assert.NoError(t, err)
+
+	summary := "### :warning: Plan\n\n" +
+		"**Plan: 2 to add, 1 to change, 2 to destroy.**\n\n" +
+		"| Resource Type | Add | Change | Destroy |\n" +
+		"| --- | ---: | ---: | ---: |\n" +
+		"| aws_instance | 2 | 0 | **1** |\n" +
+		"| aws_s3_bucket | 0 | 1 | **1** |\n" +
+		"\n:warning: **The following resources will be destroyed:**\n\n" +
+		"- `aws_instance.db` (replace)\n" +
+		"- `aws_s3_bucket.old`\n"
+
+	tests := []struct {
+		name   string
+		output string
+		size   int
+		want   string
+	}{
+		{
+			name: "change list",
+			size: 10000,
+			want: summary + "\n<details>\n<summary>Show plan</summary>\n\n```diff\n" +
+				"+ aws_instance.web\n-/+ aws_instance.db\n~ aws_s3_bucket.logs\n- aws_s3_bucket.old" +
+				"\n```\n\n</details>\n",
+		},
+		{
+			name:   "plan output",
+			output: "\x1b[1mTerraform will perform the following actions:\x1b[0m\n",
+			size:   10000,
+			want: summary + "\n<details>\n<summary>Show plan</summary>\n\n```\n" +
+				"Terraform will perform the following actions:" +
+				"\n```\n\n</details>\n",
+		},
+		{
+			name:   "output omitted",
+			output: "Terraform will perform the following actions:",
+			size:   len(summary) + 100,
+			want:   summary + "\n_Plan output not shown._\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, plan.Markdown(tt.output, tt.size))
+		})
+	}
+
+	assert.Equal(t, "### :white_check_mark: Plan\n\nNo changes. Your infrastructure matches the configuration.\n",
+		(&Terraform{}).Markdown("", 10000))
+}
+
+func TestTerraform_Markdown_Truncate(t *testing.T) {
+	plan := &Terraform{
+		Changes: []TerraformChange{{Address: "aws_instance.web", Type: "aws_instance", Action: ActionCreate}},
+	}
+	output := strings.Repeat("  + resource\n", 1000)
+
+	got := plan.Markdown(output, 1000)
+	assert.LessOrEqual(t, len(got), 1000)
+	assert.Contains(t, got, "**Plan: 1 to add, 0 to change, 0 to destroy.**\n")
+	assert.Contains(t, got, "\n...\n```\n\n</details>\n")
+}
+
+func TestTerraform_Markdown_Destroy(t *testing.T) {
+	plan := &Terraform{}
+	for i := range 100 {
+		plan.Changes = append(plan.Changes, TerraformChange{
+			Address: fmt.Sprintf("aws_instance.web[%d]", i), Type: "aws_instance", Action: ActionDelete,
+		})
+	}
+
+	got := plan.Markdown("", 1000)
+	assert.LessOrEqual(t, len(got), 1000)
+	assert.Contains(t, got, "- `aws_instance.web[0]`\n")
+	assert.NotContains(t, got, "`aws_instance.web[99]`")
+	assert.Contains(t, got, " more resources not shown._\n")
+	assert.True(t, strings.HasSuffix(got, "\n_Plan output not shown._\n"))
+}
+
+func TestTerraform_Markdown_Backticks(t *testing.T) {
+	plan := &Terraform{
+		Changes: []TerraformChange{{Address: "aws_s3_bucket.b[\"a`b\"]", Type: "aws_s3_bucket", Action: ActionDelete}},
+	}
+	output := "Terraform will perform the following actions:\n```\n  - bucket\n```"
+
+	got := plan.Markdown(output, 10000)
+	assert.Contains(t, got, "- `` aws_s3_bucket.b[\"a`b\"] ``\n")
+	assert.Contains(t, got, "\n````\nTerraform will perform the following actions:\n```\n")
+	assert.True(t, strings.HasSuffix(got, "\n```\n````\n\n</details>\n"))
+}